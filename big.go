@@ -0,0 +1,164 @@
+package godilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/bigmat"
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/sqrtm"
+    "gonum.org/v1/gonum/mat"
+    "time"
+)
+
+const (
+    // precision in bits of UnitaryNDilationBigFloat, unless set by WithPrecision
+    DefaultPrecision uint = 256
+    // smallest precision in bits, which converts float64 input exactly
+    MinPrecision uint = 53
+)
+
+// returned for a precision below MinPrecision
+type PrecisionError struct {
+    Precision uint
+}
+
+func (e *PrecisionError) Error() string {
+    return fmt.Sprintf("Unexpected precision: %d (Expecting at least %d bits)", e.Precision, MinPrecision)
+}
+
+// sets the precision in bits of UnitaryNDilationBigFloat, the default is DefaultPrecision
+func WithPrecision(prec uint) Option {
+    return func(o *options) {
+        o.precision = prec
+    }
+}
+
+func (o *options) bigSquareRoot(c *bigmat.Dense) (*bigmat.Dense, error) {
+    start := time.Now()
+    sq, result, err := sqrtm.SqrtBig(c, &sqrtm.Settings{OnIteration: o.observer.Iteration})
+    o.observer.StageDone(StageEvent{Stage: StageSquareRoot, Duration: time.Since(start), SquareRoot: result, Err: err})
+    return sq, err
+}
+
+func (o *options) bigIsPositiveDefinite(candidate *bigmat.Dense) (bool, error) {
+    start := time.Now()
+    pd := bigmat.IsPositiveDefinite(candidate)
+    o.observer.StageDone(StageEvent{Stage: StagePositiveDefiniteCheck, Duration: time.Since(start), PositiveDefinite: pd})
+    return pd, nil
+}
+
+func (o *options) bigDefects() func(*bigmat.Dense) (*bigmat.Dense, *bigmat.Dense, error) {
+    calculate := dilation.BigSquareRootDefects(o.bigIsPositiveDefinite, o.bigSquareRoot)
+
+    return func(t *bigmat.Dense) (*bigmat.Dense, *bigmat.Dense, error) {
+        start := time.Now()
+        defect, defectOfTransposed, err := calculate(t)
+        o.observer.StageDone(StageEvent{Stage: StageDefects, Duration: time.Since(start), Err: err})
+        return defect, defectOfTransposed, err
+    }
+}
+
+func (o *options) newBigBlockMatrix(rows [][]*bigmat.Dense) (*bigmat.Dense, error) {
+    start := time.Now()
+    d, err := bigmat.NewBlockMatrixFromSquares(rows)
+    o.observer.StageDone(StageEvent{Stage: StageBlockAssembly, Duration: time.Since(start), Err: err})
+    return d, err
+}
+
+/*
+    Same as UnitaryNDilation, but calculates the defect operators, the positive definite check, the square roots and the block assembly
+    with math/big.Float of the precision set by WithPrecision. The square roots are calculated with the Denman–Beavers iteration,
+    which reaches the precision of the calculation for ill-conditioned defect operators as well. WithBackend is ignored.
+    Unlike UnitaryNDilation, t must be square, a rectangular t results in the error "Matrix does not have square dimension".
+    Returns the dilation in big precision and rounded to float64, or a *PrecisionError for a precision below MinPrecision.
+    With WithNearestContraction the projection is calculated in float64 before t is converted to big precision.
+    WithSpecialOrthogonal negates the last column in big precision, the sign of the determinant is calculated in the same precision.
+*/
+func UnitaryNDilationBigFloat(t mat.Matrix, n int, opts ...Option) (dilationBig *bigmat.Dense, rounded *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return nil, nil, err
+    }
+
+    o := newOptions(opts)
+
+    if o.precision < MinPrecision {
+        return nil, nil, &PrecisionError{Precision: o.precision}
+    }
+
+    if t, err = o.projected(t); err != nil {
+        return nil, nil, err
+    }
+
+    unitary, err := dilation.UnitaryNDilationBig(o.bigDefects(), o.newBigBlockMatrix, bigmat.NewFromMatrix(t, o.precision), n)
+
+    if err != nil {
+        return nil, nil, err
+    }
+
+    if o.special {
+        dilation.SpecialOrthogonalBig(unitary)
+    }
+
+    return unitary, unitary.Float64(), nil
+}
+
+func (o *options) ratIsPositiveDefinite(candidate *bigmat.RatDense) bool {
+    start := time.Now()
+    pd := bigmat.RatIsPositiveDefinite(candidate)
+    o.observer.StageDone(StageEvent{Stage: StagePositiveDefiniteCheck, Duration: time.Since(start), PositiveDefinite: pd})
+    return pd
+}
+
+func (o *options) ratDefects() func(*bigmat.RatDense) (*bigmat.Dense, *bigmat.Dense, error) {
+    calculate := dilation.RatSquareRootDefects(o.ratIsPositiveDefinite, o.bigSquareRoot, o.precision)
+
+    return func(t *bigmat.RatDense) (*bigmat.Dense, *bigmat.Dense, error) {
+        start := time.Now()
+        defect, defectOfTransposed, err := calculate(t)
+        o.observer.StageDone(StageEvent{Stage: StageDefects, Duration: time.Since(start), Err: err})
+        return defect, defectOfTransposed, err
+    }
+}
+
+/*
+    Same as UnitaryNDilationBigFloat for a contraction t with rational entries. I - TTᵀ and I - TᵀT are calculated exactly
+    and the decision whether t is a contraction is made exactly by an LDLᵀ decomposition in rational arithmetic, so it is never wrong due to rounding.
+    Only the square roots are calculated in the precision set by WithPrecision, and t is rounded to it in the dilation.
+    Use bigmat.NewRatDense for exact fractions such as 1/10 or bigmat.NewRatFromMatrix for float64 entries. T must be square as well.
+    WithNearestContraction results in an *OptionError, as the projection would not be exact.
+*/
+func UnitaryNDilationRat(t *bigmat.RatDense, n int, opts ...Option) (dilationBig *bigmat.Dense, rounded *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if t == nil {
+        return nil, nil, ErrNilMatrix
+    }
+
+    if n < 1 {
+        return nil, nil, &DegreeError{Degree: n}
+    }
+
+    o := newOptions(opts)
+
+    if o.precision < MinPrecision {
+        return nil, nil, &PrecisionError{Precision: o.precision}
+    }
+
+    if err := o.rejectProjection("the projection of a rational contraction is not exact"); err != nil {
+        return nil, nil, err
+    }
+
+    unitary, err := dilation.UnitaryNDilationRat(o.ratDefects(), o.newBigBlockMatrix, t, n, o.precision)
+
+    if err != nil {
+        return nil, nil, err
+    }
+
+    if o.special {
+        dilation.SpecialOrthogonalBig(unitary)
+    }
+
+    return unitary, unitary.Float64(), nil
+}
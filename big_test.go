@@ -0,0 +1,246 @@
+package godilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/bigmat"
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+    "reflect"
+    "testing"
+)
+
+// returns ‖UᵀU − I‖_F in the precision of u
+func bigUnitarityDeviation(u *bigmat.Dense) float64 {
+    n, _ := u.Dims()
+    deviation, _ := bigmat.Sub(bigmat.Mul(u.T(), u), bigmat.Identity(n, u.Prec())).Norm().Float64()
+    return deviation
+}
+
+// returns ‖UᵀU − I‖_F in float64
+func unitarityDeviation(u *mat.Dense) float64 {
+    n, _ := u.Dims()
+    var product mat.Dense
+    product.Mul(u.T(), u)
+
+    for i := 0; i < n; i++ {
+        product.Set(i, i, product.At(i, i) - 1)
+    }
+
+    return mat.Norm(&product, 2)
+}
+
+func TestUnitaryNDilationBigFloat(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+    }{
+        {desc: "non normal matrix", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 3},
+        {desc: "symmetric matrix", value: mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,}), degree: 2},
+        {desc: "random contraction", value: randomContraction(4, 0.9), degree: 2},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitaryBig, unitary, err := UnitaryNDilationBigFloat(table.value, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if unitaryBig.Prec() != DefaultPrecision {
+                t.Errorf("Wrong precision, got: %d, want: %d", unitaryBig.Prec(), DefaultPrecision)
+            }
+
+            if deviation := bigUnitarityDeviation(unitaryBig); deviation > 1e-60 {
+                t.Errorf("Dilation is not unitary in big precision, deviation: %e", deviation)
+            }
+
+            isNDilation(t, unitary, table.value, table.degree, 1e-14)
+        })
+    }
+}
+
+func TestUnitaryNDilationBigFloatHardCases(t *testing.T) {
+    tables := []struct {
+        desc string
+        eigenvalues []float64
+    }{
+        {desc: "defect eigenvalues down to 1e-10", eigenvalues: []float64{1e-10, 1e-8, 1e-6, 1e-4, 1e-2, 1}},
+        {desc: "defect eigenvalues down to 1e-14", eigenvalues: []float64{1e-14, 1e-11, 1e-8, 1e-5, 1e-2, 1}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            value := contractionWithDefectEigenvalues(table.eigenvalues)
+
+            unitaryBig, rounded, err := UnitaryNDilationBigFloat(value, 2, WithPrecision(512))
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if deviation := bigUnitarityDeviation(unitaryBig); deviation > 1e-100 {
+                t.Errorf("Dilation is not unitary in big precision, deviation: %e", deviation)
+            }
+
+            // rounding the big result is the best float64 approximation, so it is at least as unitary as the float64 backends
+            for _, backend := range []Backend{ExponentialMethod, SingularValueDecomposition} {
+                unitary, err := UnitaryNDilation(value, 2, WithBackend(backend))
+
+                if err != nil {
+                    t.Fatalf("Unexpected error of backend %d: %v", backend, err)
+                }
+
+                if !mat.EqualApprox(unitary, rounded, 1e-6) {
+                    t.Errorf("Backend %d deviates from the big result: %v", backend, mat.Formatted(unitary))
+                }
+
+                if got, reference := unitarityDeviation(rounded), unitarityDeviation(unitary); got > 2 * reference {
+                    t.Errorf("Rounded big result is less unitary than backend %d: %e > %e", backend, got, reference)
+                }
+            }
+
+            if deviation := unitarityDeviation(rounded); deviation > 1e-14 {
+                t.Errorf("Rounded dilation is not unitary, deviation: %e", deviation)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationBigFloatErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        opts []Option
+        expected error
+    }{
+        {desc: "not a contraction", value: mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,}), expected: fmt.Errorf("Input is not a contraction")},
+        {desc: "norm 1", value: mat.NewDense(2, 2, []float64{1,0,0,0.5,}), expected: fmt.Errorf("Input is not a contraction")},
+        {desc: "not square", value: mat.NewDense(1, 2, []float64{0.5, 0}), expected: fmt.Errorf("Matrix does not have square dimension")},
+        {desc: "precision", value: mat.NewDense(1, 1, []float64{0.5}), opts: []Option{WithPrecision(52)}, expected: &PrecisionError{Precision: 52}},
+        {desc: "validates input", value: nil, expected: ErrNilMatrix},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitaryBig, unitary, err := UnitaryNDilationBigFloat(table.value, 2, table.opts...)
+
+            if !reflect.DeepEqual(err, table.expected) || unitaryBig != nil || unitary != nil {
+                t.Errorf("Wrong result, got: %v, %v, %v, want: nil, nil, %v", unitaryBig, unitary, err, table.expected)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationBigFloatObserver(t *testing.T) {
+    observer := &recordingObserver{}
+
+    if _, _, err := UnitaryNDilationBigFloat(mat.NewDense(1, 1, []float64{0.5}), 2, WithObserver(observer)); err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := []Stage{StagePositiveDefiniteCheck, StageSquareRoot, StageSquareRoot, StageDefects, StageBlockAssembly}
+
+    if got := observer.stageOrder(); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Wrong stages, got: %v, want: %v", got, expected)
+    }
+
+    if len(observer.iterations) == 0 {
+        t.Errorf("Iterations of the square roots were not observed")
+    }
+}
+
+func ratMatrix(r, c int, values ...string) *bigmat.RatDense {
+    data := make([]*big.Rat, len(values))
+
+    for i, v := range values {
+        data[i], _ = new(big.Rat).SetString(v)
+    }
+
+    return bigmat.NewRatDense(r, c, data)
+}
+
+func TestUnitaryNDilationRat(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *bigmat.RatDense
+        degree int
+    }{
+        {desc: "matrix of examples/basic.go", value: ratMatrix(2, 2, "1/2", "1/2", "0", "1/2"), degree: 2},
+        {desc: "decimal fractions", value: ratMatrix(2, 2, "1/10", "3/10", "0", "7/10"), degree: 3},
+        {desc: "norm close to 1", value: ratMatrix(1, 1, "999999999999/1000000000000"), degree: 1},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitaryBig, unitary, err := UnitaryNDilationRat(table.value, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if deviation := bigUnitarityDeviation(unitaryBig); deviation > 1e-60 {
+                t.Errorf("Dilation is not unitary in big precision, deviation: %e", deviation)
+            }
+
+            if deviation := unitarityDeviation(unitary); deviation > 1e-15 {
+                t.Errorf("Rounded dilation is not unitary, deviation: %e", deviation)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationRatExactDecision(t *testing.T) {
+    // both round to 1 in float64, which cannot tell the contraction from the matrix of norm 1 + 10^-30
+    aboveOne := ratMatrix(1, 1, "1000000000000000000000000000001/1000000000000000000000000000000")
+    belowOne := ratMatrix(1, 1, "999999999999999999999999999999/1000000000000000000000000000000")
+    expectedErr := fmt.Errorf("Input is not a contraction")
+
+    if _, _, err := UnitaryNDilationRat(aboveOne, 2); !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("Wrong error, got: %v, want: %v", err, expectedErr)
+    }
+
+    if _, _, err := UnitaryNDilationRat(ratMatrix(2, 2, "3/5", "0", "4/5", "0"), 2); !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("Matrix with norm exactly 1 accepted, got: %v", err)
+    }
+
+    if _, _, err := UnitaryNDilationRat(belowOne, 2, WithPrecision(512)); err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
+
+func TestUnitaryNDilationRatErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *bigmat.RatDense
+        degree int
+        opts []Option
+        expected error
+    }{
+        {desc: "nil", value: nil, degree: 2, expected: ErrNilMatrix},
+        {desc: "degree", value: ratMatrix(1, 1, "1/2"), degree: 0, expected: &DegreeError{Degree: 0}},
+        {desc: "precision", value: ratMatrix(1, 1, "1/2"), degree: 2, opts: []Option{WithPrecision(10)}, expected: &PrecisionError{Precision: 10}},
+        {desc: "not square", value: ratMatrix(1, 2, "1/2", "0"), degree: 2, expected: fmt.Errorf("Matrix does not have square dimension")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitaryBig, unitary, err := UnitaryNDilationRat(table.value, table.degree, table.opts...)
+
+            if !reflect.DeepEqual(err, table.expected) || unitaryBig != nil || unitary != nil {
+                t.Errorf("Wrong result, got: %v, %v, %v, want: nil, nil, %v", unitaryBig, unitary, err, table.expected)
+            }
+        })
+    }
+}
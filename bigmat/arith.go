@@ -0,0 +1,236 @@
+package bigmat
+
+import (
+    "errors"
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+)
+
+// returned by Inverse for a matrix that is singular in the precision of the calculation
+var ErrSingular = errors.New("Matrix is singular")
+
+// returns the larger precision of a and b
+func maxPrec(a, b *Dense) uint {
+    if a.prec > b.prec {
+        return a.prec
+    }
+    return b.prec
+}
+
+// returns a + b, panics with mat.ErrShape for different dimensions
+func Add(a, b *Dense) *Dense {
+    if a.rows != b.rows || a.cols != b.cols {
+        panic(mat.ErrShape)
+    }
+
+    sum := NewDense(a.rows, a.cols, maxPrec(a, b))
+
+    for i := range sum.data {
+        sum.data[i].Add(a.data[i], b.data[i])
+    }
+
+    return sum
+}
+
+// returns a - b, panics with mat.ErrShape for different dimensions
+func Sub(a, b *Dense) *Dense {
+    if a.rows != b.rows || a.cols != b.cols {
+        panic(mat.ErrShape)
+    }
+
+    diff := NewDense(a.rows, a.cols, maxPrec(a, b))
+
+    for i := range diff.data {
+        diff.data[i].Sub(a.data[i], b.data[i])
+    }
+
+    return diff
+}
+
+// returns f * a
+func Scale(f *big.Float, a *Dense) *Dense {
+    scaled := NewDense(a.rows, a.cols, a.prec)
+
+    for i := range scaled.data {
+        scaled.data[i].Mul(f, a.data[i])
+    }
+
+    return scaled
+}
+
+// returns the product a * b, panics with mat.ErrShape if the columns of a do not match the rows of b
+func Mul(a, b *Dense) *Dense {
+    if a.cols != b.rows {
+        panic(mat.ErrShape)
+    }
+
+    product := NewDense(a.rows, b.cols, maxPrec(a, b))
+    term := new(big.Float).SetPrec(product.prec)
+
+    for i := 0; i < a.rows; i++ {
+        for j := 0; j < b.cols; j++ {
+            entry := product.data[i * b.cols + j]
+
+            for k := 0; k < a.cols; k++ {
+                entry.Add(entry, term.Mul(a.data[i * a.cols + k], b.data[k * b.cols + j]))
+            }
+        }
+    }
+
+    return product
+}
+
+// returns the inverse of the square matrix a by Gauss-Jordan elimination with partial pivoting or ErrSingular
+func Inverse(a *Dense) (*Dense, error) {
+    if a.rows != a.cols {
+        panic(mat.ErrSquare)
+    }
+
+    n := a.rows
+    work := a.Clone()
+    inverse := Identity(n, a.prec)
+    factor := new(big.Float).SetPrec(a.prec)
+    term := new(big.Float).SetPrec(a.prec)
+    abs := new(big.Float).SetPrec(a.prec)
+    pivotAbs := new(big.Float).SetPrec(a.prec)
+
+    swapRows := func(d *Dense, i, j int) {
+        for k := 0; k < n; k++ {
+            d.data[i * n + k], d.data[j * n + k] = d.data[j * n + k], d.data[i * n + k]
+        }
+    }
+
+    for col := 0; col < n; col++ {
+        pivot := col
+        pivotAbs.Abs(work.data[col * n + col])
+
+        for i := col + 1; i < n; i++ {
+            if abs.Abs(work.data[i * n + col]).Cmp(pivotAbs) > 0 {
+                pivot = i
+                pivotAbs.Set(abs)
+            }
+        }
+
+        if pivotAbs.Sign() == 0 {
+            return nil, ErrSingular
+        }
+
+        swapRows(work, col, pivot)
+        swapRows(inverse, col, pivot)
+
+        factor.Quo(big.NewFloat(1).SetPrec(a.prec), work.data[col * n + col])
+
+        for k := 0; k < n; k++ {
+            work.data[col * n + k].Mul(work.data[col * n + k], factor)
+            inverse.data[col * n + k].Mul(inverse.data[col * n + k], factor)
+        }
+
+        for i := 0; i < n; i++ {
+            if i == col || work.data[i * n + col].Sign() == 0 {
+                continue
+            }
+
+            factor.Set(work.data[i * n + col])
+
+            for k := 0; k < n; k++ {
+                work.data[i * n + k].Sub(work.data[i * n + k], term.Mul(factor, work.data[col * n + k]))
+                inverse.data[i * n + k].Sub(inverse.data[i * n + k], term.Mul(factor, inverse.data[col * n + k]))
+            }
+        }
+    }
+
+    return inverse, nil
+}
+
+// returns the sign of the determinant of the square matrix a, calculated in its precision by an LU decomposition with partial pivoting, or 0 if a is singular
+func DeterminantSign(a *Dense) int {
+    if a.rows != a.cols {
+        panic(mat.ErrSquare)
+    }
+
+    n := a.rows
+    work := a.Clone()
+    factor := new(big.Float).SetPrec(a.prec)
+    term := new(big.Float).SetPrec(a.prec)
+    abs := new(big.Float).SetPrec(a.prec)
+    pivotAbs := new(big.Float).SetPrec(a.prec)
+    sign := 1
+
+    for col := 0; col < n; col++ {
+        pivot := col
+        pivotAbs.Abs(work.data[col * n + col])
+
+        for i := col + 1; i < n; i++ {
+            if abs.Abs(work.data[i * n + col]).Cmp(pivotAbs) > 0 {
+                pivot = i
+                pivotAbs.Set(abs)
+            }
+        }
+
+        if pivotAbs.Sign() == 0 {
+            return 0
+        }
+
+        if pivot != col {
+            for k := 0; k < n; k++ {
+                work.data[col * n + k], work.data[pivot * n + k] = work.data[pivot * n + k], work.data[col * n + k]
+            }
+            sign = -sign
+        }
+
+        sign *= work.data[col * n + col].Sign()
+
+        for i := col + 1; i < n; i++ {
+            factor.Quo(work.data[i * n + col], work.data[col * n + col])
+
+            for k := col + 1; k < n; k++ {
+                work.data[i * n + k].Sub(work.data[i * n + k], term.Mul(factor, work.data[col * n + k]))
+            }
+        }
+    }
+
+    return sign
+}
+
+/*
+    Reports whether the symmetric matrix a is positive definite by attempting its Cholesky decomposition a = LLᵀ,
+    which exists with positive diagonal entries of L if and only if a is positive definite.
+    Only the lower triangle of a is read.
+*/
+func IsPositiveDefinite(a *Dense) bool {
+    if a.rows != a.cols {
+        panic(mat.ErrSquare)
+    }
+
+    n := a.rows
+    l := NewDense(n, n, a.prec)
+    sum := new(big.Float).SetPrec(a.prec)
+    term := new(big.Float).SetPrec(a.prec)
+
+    for j := 0; j < n; j++ {
+        sum.Set(a.data[j * n + j])
+
+        for k := 0; k < j; k++ {
+            sum.Sub(sum, term.Mul(l.data[j * n + k], l.data[j * n + k]))
+        }
+
+        if sum.Sign() <= 0 {
+            return false
+        }
+
+        diagonal := l.data[j * n + j]
+        diagonal.Sqrt(sum)
+
+        for i := j + 1; i < n; i++ {
+            sum.Set(a.data[i * n + j])
+
+            for k := 0; k < j; k++ {
+                sum.Sub(sum, term.Mul(l.data[i * n + k], l.data[j * n + k]))
+            }
+
+            l.data[i * n + j].Quo(sum, diagonal)
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,104 @@
+package bigmat
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/big"
+    "testing"
+)
+
+func TestArithmetic(t *testing.T) {
+    a := NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 3, 4}), 64)
+    b := NewFromMatrix(mat.NewDense(2, 2, []float64{0, 1, 1, 0}), 64)
+
+    tables := []struct {
+        desc string
+        value *Dense
+        expected *mat.Dense
+    }{
+        {desc: "Add", value: Add(a, b), expected: mat.NewDense(2, 2, []float64{1, 3, 4, 4})},
+        {desc: "Sub", value: Sub(a, b), expected: mat.NewDense(2, 2, []float64{1, 1, 2, 4})},
+        {desc: "Mul", value: Mul(a, b), expected: mat.NewDense(2, 2, []float64{2, 1, 4, 3})},
+        {desc: "Scale", value: Scale(big.NewFloat(0.5), a), expected: mat.NewDense(2, 2, []float64{0.5, 1, 1.5, 2})},
+    }
+
+    for _, table := range tables {
+        if !mat.Equal(table.value.Float64(), table.expected) {
+            t.Errorf("%s returned wrong value, got: %v, want: %v", table.desc, mat.Formatted(table.value.Float64()), mat.Formatted(table.expected))
+        }
+    }
+}
+
+func TestMulPanicsOnShape(t *testing.T) {
+    defer func() {
+        if r := recover(); r != mat.ErrShape {
+            t.Errorf("Wrong panic, got: %v", r)
+        }
+    }()
+    Mul(NewDense(2, 3, 64), NewDense(2, 3, 64))
+}
+
+func TestInverse(t *testing.T) {
+    // the float64 inverse of this matrix is inaccurate, in 256 bits the product with the inverse is the identity up to 1e-60
+    a := NewFromMatrix(mat.NewDense(3, 3, []float64{1, 1, 1, 1, 1 + 1e-12, 1, 0, 1, 2}), 256)
+    inverse, err := Inverse(a)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    deviation, _ := Sub(Mul(a, inverse), Identity(3, 256)).Norm().Float64()
+
+    if deviation > 1e-60 {
+        t.Errorf("Product with inverse deviates from identity by %e", deviation)
+    }
+
+    if _, err := Inverse(NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 2, 4}), 64)); err != ErrSingular {
+        t.Errorf("Wrong error, got: %v, want: %v", err, ErrSingular)
+    }
+}
+
+func TestDeterminantSign(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        expected int
+    }{
+        {desc: "identity", value: mat.NewDense(2, 2, []float64{1, 0, 0, 1}), expected: 1},
+        {desc: "exchange", value: mat.NewDense(2, 2, []float64{0, 1, 1, 0}), expected: -1},
+        {desc: "reflection", value: mat.NewDense(2, 2, []float64{0.6, 0.8, 0.8, -0.6}), expected: -1},
+        // the determinant is 2e-12
+        {desc: "nearly singular", value: mat.NewDense(3, 3, []float64{1, 1, 1, 1, 1 + 1e-12, 1, 0, 1, 2}), expected: 1},
+        {desc: "singular", value: mat.NewDense(2, 2, []float64{1, 2, 2, 4}), expected: 0},
+    }
+
+    for _, table := range tables {
+        if got := DeterminantSign(NewFromMatrix(table.value, 256)); got != table.expected {
+            t.Errorf("Wrong sign for %s, got: %d, want: %d", table.desc, got, table.expected)
+        }
+    }
+}
+
+func TestIsPositiveDefinite(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        expected bool
+    }{
+        {desc: "positive definite", value: mat.NewDense(2, 2, []float64{2, 1, 1, 2}), expected: true},
+        {desc: "barely positive definite", value: mat.NewDense(2, 2, []float64{1, 1, 1, 1 + 1e-15}), expected: true},
+        {desc: "singular", value: mat.NewDense(2, 2, []float64{1, 1, 1, 1}), expected: false},
+        {desc: "indefinite", value: mat.NewDense(2, 2, []float64{1, 2, 2, 1}), expected: false},
+        {desc: "negative definite", value: mat.NewDense(1, 1, []float64{-math.SmallestNonzeroFloat64}), expected: false},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            if got := IsPositiveDefinite(NewFromMatrix(table.value, 128)); got != table.expected {
+                t.Errorf("IsPositiveDefinite was incorrect, got: %t, want: %t", got, table.expected)
+            }
+        })
+    }
+}
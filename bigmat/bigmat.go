@@ -0,0 +1,154 @@
+/*
+    Package bigmat provides dense matrices of math/big.Float entries with a fixed precision,
+    which serve as reference for calculations that are not accurate enough in float64.
+*/
+package bigmat
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+)
+
+// A dense matrix whose entries are big.Float values with the precision of the matrix
+type Dense struct {
+    rows, cols int
+    prec uint
+    data []*big.Float
+}
+
+func checkDims(r, c int) {
+    if r <= 0 || c <= 0 {
+        if r == 0 || c == 0 {
+            panic(mat.ErrZeroLength)
+        }
+        panic(mat.ErrNegativeDimension)
+    }
+}
+
+// returns the zero matrix of dimension (r, c) with entries of precision prec in bits
+func NewDense(r, c int, prec uint) *Dense {
+    checkDims(r, c)
+    data := make([]*big.Float, r * c)
+
+    for i := range data {
+        data[i] = new(big.Float).SetPrec(prec)
+    }
+
+    return &Dense{rows: r, cols: c, prec: prec, data: data}
+}
+
+// returns the identity matrix of dimension (n, n) with entries of precision prec in bits
+func Identity(n int, prec uint) *Dense {
+    d := NewDense(n, n, prec)
+
+    for i := 0; i < n; i++ {
+        d.data[i * n + i].SetInt64(1)
+    }
+
+    return d
+}
+
+// returns the entries of m with precision prec in bits, the conversion is exact for prec >= 53
+func NewFromMatrix(m mat.Matrix, prec uint) *Dense {
+    r, c := m.Dims()
+    d := NewDense(r, c, prec)
+
+    for i := 0; i < r; i++ {
+        for j := 0; j < c; j++ {
+            d.data[i * c + j].SetFloat64(m.At(i, j))
+        }
+    }
+
+    return d
+}
+
+func (d *Dense) Dims() (r, c int) {
+    return d.rows, d.cols
+}
+
+// returns the precision of the entries in bits
+func (d *Dense) Prec() uint {
+    return d.prec
+}
+
+func (d *Dense) checkIndex(i, j int) {
+    if i < 0 || i >= d.rows {
+        panic(mat.ErrRowAccess)
+    }
+
+    if j < 0 || j >= d.cols {
+        panic(mat.ErrColAccess)
+    }
+}
+
+// returns a copy of the entry (i, j)
+func (d *Dense) At(i, j int) *big.Float {
+    d.checkIndex(i, j)
+    return new(big.Float).Copy(d.data[i * d.cols + j])
+}
+
+// sets the entry (i, j) to v, rounded to the precision of d
+func (d *Dense) Set(i, j int, v *big.Float) {
+    d.checkIndex(i, j)
+    d.data[i * d.cols + j].Set(v)
+}
+
+// returns a copy of d
+func (d *Dense) Clone() *Dense {
+    c := NewDense(d.rows, d.cols, d.prec)
+
+    for i, v := range d.data {
+        c.data[i].Set(v)
+    }
+
+    return c
+}
+
+// returns the transpose of d as a new matrix
+func (d *Dense) T() *Dense {
+    t := NewDense(d.cols, d.rows, d.prec)
+
+    for i := 0; i < d.rows; i++ {
+        for j := 0; j < d.cols; j++ {
+            t.data[j * d.rows + i].Set(d.data[i * d.cols + j])
+        }
+    }
+
+    return t
+}
+
+// returns the entries of d rounded to the nearest float64
+func (d *Dense) Float64() *mat.Dense {
+    data := make([]float64, len(d.data))
+
+    for i, v := range d.data {
+        data[i], _ = v.Float64()
+    }
+
+    return mat.NewDense(d.rows, d.cols, data)
+}
+
+// copies b into d, starting at the entry (i, j). Panics with mat.ErrShape, if b does not fit into d
+func (d *Dense) SetSlice(i, j int, b *Dense) {
+    if i < 0 || j < 0 || i + b.rows > d.rows || j + b.cols > d.cols {
+        panic(mat.ErrShape)
+    }
+
+    for k := 0; k < b.rows; k++ {
+        for l := 0; l < b.cols; l++ {
+            d.data[(i + k) * d.cols + j + l].Set(b.data[k * b.cols + l])
+        }
+    }
+}
+
+// returns the Frobenius norm of d
+func (d *Dense) Norm() *big.Float {
+    sum := new(big.Float).SetPrec(d.prec)
+    square := new(big.Float).SetPrec(d.prec)
+
+    for _, v := range d.data {
+        sum.Add(sum, square.Mul(v, v))
+    }
+
+    return sum.Sqrt(sum)
+}
@@ -0,0 +1,71 @@
+package bigmat
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+    "testing"
+)
+
+func TestNewFromMatrix(t *testing.T) {
+    value := mat.NewDense(2, 3, []float64{1, 0.1, -3, 1e-300, 5, 6})
+    d := NewFromMatrix(value, 64)
+
+    if r, c := d.Dims(); r != 2 || c != 3 || d.Prec() != 64 {
+        t.Errorf("Wrong dimension or precision, got: (%d, %d), %d", r, c, d.Prec())
+    }
+
+    if !mat.Equal(d.Float64(), value) {
+        t.Errorf("Conversion is not exact, got: %v, want: %v", mat.Formatted(d.Float64()), mat.Formatted(value))
+    }
+
+    if !mat.Equal(d.T().Float64(), value.T()) {
+        t.Errorf("Wrong transpose, got: %v", mat.Formatted(d.T().Float64()))
+    }
+}
+
+func TestAtReturnsCopy(t *testing.T) {
+    d := Identity(2, 64)
+    d.At(0, 0).SetInt64(5)
+
+    if d.At(0, 0).Cmp(big.NewFloat(1)) != 0 {
+        t.Errorf("At returned a reference into the matrix")
+    }
+
+    d.Set(1, 0, big.NewFloat(3))
+
+    if !mat.Equal(d.Float64(), mat.NewDense(2, 2, []float64{1, 0, 3, 1})) {
+        t.Errorf("Wrong matrix after Set, got: %v", mat.Formatted(d.Float64()))
+    }
+
+    defer func() {
+        if r := recover(); r != mat.ErrRowAccess {
+            t.Errorf("Wrong panic, got: %v", r)
+        }
+    }()
+    d.At(2, 0)
+}
+
+func TestSetSlice(t *testing.T) {
+    d := NewDense(3, 3, 64)
+    d.SetSlice(1, 1, NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 3, 4}), 64))
+    expected := mat.NewDense(3, 3, []float64{0, 0, 0, 0, 1, 2, 0, 3, 4})
+
+    if !mat.Equal(d.Float64(), expected) {
+        t.Errorf("Wrong matrix, got: %v, want: %v", mat.Formatted(d.Float64()), mat.Formatted(expected))
+    }
+
+    defer func() {
+        if r := recover(); r != mat.ErrShape {
+            t.Errorf("Wrong panic, got: %v", r)
+        }
+    }()
+    d.SetSlice(2, 2, Identity(2, 64))
+}
+
+func TestNorm(t *testing.T) {
+    norm, _ := NewFromMatrix(mat.NewDense(2, 2, []float64{3, 0, 0, 4}), 64).Norm().Float64()
+
+    if norm != 5 {
+        t.Errorf("Wrong norm, got: %v, want: 5", norm)
+    }
+}
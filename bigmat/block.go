@@ -0,0 +1,55 @@
+package bigmat
+
+import (
+    "errors"
+    "fmt"
+)
+
+// returned by NewBlockMatrixFromSquares if there are no rows of blocks
+var ErrNoBlocks = errors.New("Block matrix has no blocks")
+
+// returns the block matrix of the square blocks in rows, which need to have the same dimension, with the largest precision of all blocks
+func NewBlockMatrixFromSquares(rows [][]*Dense) (*Dense, error) {
+    n0 := len(rows)
+
+    if n0 == 0 {
+        return nil, ErrNoBlocks
+    }
+
+    var d0 int
+    var prec uint
+
+    for i, row := range rows {
+        if n := len(row); n != n0 {
+            return nil, fmt.Errorf("Unexpected length of row: %d has length %d (Expecting %d)", i, n, n0)
+        }
+
+        for j, block := range row {
+            if block == nil {
+                return nil, fmt.Errorf("Unexpected nil block in row %d, col %d", i, j)
+            }
+
+            if i == 0 && j == 0 {
+                d0, _ = block.Dims()
+            }
+
+            if d1, d2 := block.Dims(); d1 != d0 || d2 != d0 {
+                return nil, fmt.Errorf("Unexpected dimension: (%d, %d) in row %d, col %d (Expecting (%d, %d))", d1, d2, i, j, d0, d0)
+            }
+
+            if block.prec > prec {
+                prec = block.prec
+            }
+        }
+    }
+
+    d := NewDense(d0 * n0, d0 * n0, prec)
+
+    for i, row := range rows {
+        for j, block := range row {
+            d.SetSlice(i * d0, j * d0, block)
+        }
+    }
+
+    return d, nil
+}
@@ -0,0 +1,50 @@
+package bigmat
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func TestNewBlockMatrixFromSquares(t *testing.T) {
+    a := NewFromMatrix(mat.NewDense(1, 1, []float64{1}), 64)
+    b := NewFromMatrix(mat.NewDense(1, 1, []float64{2}), 128)
+
+    d, err := NewBlockMatrixFromSquares([][]*Dense{{a, b}, {b, a}})
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !mat.Equal(d.Float64(), mat.NewDense(2, 2, []float64{1, 2, 2, 1})) || d.Prec() != 128 {
+        t.Errorf("Wrong block matrix, got: %v with precision %d", mat.Formatted(d.Float64()), d.Prec())
+    }
+}
+
+func TestNewBlockMatrixFromSquaresErrors(t *testing.T) {
+    a := Identity(1, 64)
+
+    tables := []struct {
+        desc string
+        rows [][]*Dense
+        expected error
+    }{
+        {desc: "no rows", rows: nil, expected: ErrNoBlocks},
+        {desc: "row length", rows: [][]*Dense{{a, a}, {a}}, expected: fmt.Errorf("Unexpected length of row: 1 has length 1 (Expecting 2)")},
+        {desc: "nil block", rows: [][]*Dense{{a, nil}, {a, a}}, expected: fmt.Errorf("Unexpected nil block in row 0, col 1")},
+        {desc: "dimension", rows: [][]*Dense{{a, a}, {a, Identity(2, 64)}}, expected: fmt.Errorf("Unexpected dimension: (2, 2) in row 1, col 1 (Expecting (1, 1))")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            d, err := NewBlockMatrixFromSquares(table.rows)
+
+            if d != nil || !reflect.DeepEqual(err, table.expected) {
+                t.Errorf("Wrong result, got: %v, %v, want: nil, %v", d, err, table.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,177 @@
+package bigmat
+
+import (
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+)
+
+// A dense matrix of exact rational entries
+type RatDense struct {
+    rows, cols int
+    data []*big.Rat
+}
+
+// returns a matrix of dimension (r, c) with a copy of the entries of data in row-major order, or the zero matrix for nil data.
+// Panics with mat.ErrShape if data does not have r * c entries
+func NewRatDense(r, c int, data []*big.Rat) *RatDense {
+    checkDims(r, c)
+
+    if data != nil && len(data) != r * c {
+        panic(mat.ErrShape)
+    }
+
+    d := &RatDense{rows: r, cols: c, data: make([]*big.Rat, r * c)}
+
+    for i := range d.data {
+        d.data[i] = new(big.Rat)
+
+        if data != nil {
+            d.data[i].Set(data[i])
+        }
+    }
+
+    return d
+}
+
+// returns the identity matrix of dimension (n, n)
+func RatIdentity(n int) *RatDense {
+    d := NewRatDense(n, n, nil)
+
+    for i := 0; i < n; i++ {
+        d.data[i * n + i].SetInt64(1)
+    }
+
+    return d
+}
+
+// returns the exact rational value of the entries of m, or a *guard.NonFiniteError for an entry that is NaN or ±Inf.
+// Note that a float64 such as 0.1 is not exactly 1/10, use NewRatDense for exact decimal fractions
+func NewRatFromMatrix(m mat.Matrix) (*RatDense, error) {
+    if err := guard.CheckFinite(guard.StageInput, m); err != nil {
+        return nil, err
+    }
+
+    r, c := m.Dims()
+    d := NewRatDense(r, c, nil)
+
+    for i := 0; i < r; i++ {
+        for j := 0; j < c; j++ {
+            d.data[i * c + j].SetFloat64(m.At(i, j))
+        }
+    }
+
+    return d, nil
+}
+
+func (d *RatDense) Dims() (r, c int) {
+    return d.rows, d.cols
+}
+
+// returns a copy of the entry (i, j)
+func (d *RatDense) At(i, j int) *big.Rat {
+    if i < 0 || i >= d.rows {
+        panic(mat.ErrRowAccess)
+    }
+
+    if j < 0 || j >= d.cols {
+        panic(mat.ErrColAccess)
+    }
+
+    return new(big.Rat).Set(d.data[i * d.cols + j])
+}
+
+// returns the transpose of d as a new matrix
+func (d *RatDense) T() *RatDense {
+    t := NewRatDense(d.cols, d.rows, nil)
+
+    for i := 0; i < d.rows; i++ {
+        for j := 0; j < d.cols; j++ {
+            t.data[j * d.rows + i].Set(d.data[i * d.cols + j])
+        }
+    }
+
+    return t
+}
+
+// returns the entries of d rounded to the precision prec in bits
+func (d *RatDense) Dense(prec uint) *Dense {
+    dense := NewDense(d.rows, d.cols, prec)
+
+    for i, v := range d.data {
+        dense.data[i].SetRat(v)
+    }
+
+    return dense
+}
+
+// returns a - b exactly, panics with mat.ErrShape for different dimensions
+func RatSub(a, b *RatDense) *RatDense {
+    if a.rows != b.rows || a.cols != b.cols {
+        panic(mat.ErrShape)
+    }
+
+    diff := NewRatDense(a.rows, a.cols, nil)
+
+    for i := range diff.data {
+        diff.data[i].Sub(a.data[i], b.data[i])
+    }
+
+    return diff
+}
+
+// returns the product a * b exactly, panics with mat.ErrShape if the columns of a do not match the rows of b
+func RatMul(a, b *RatDense) *RatDense {
+    if a.cols != b.rows {
+        panic(mat.ErrShape)
+    }
+
+    product := NewRatDense(a.rows, b.cols, nil)
+    term := new(big.Rat)
+
+    for i := 0; i < a.rows; i++ {
+        for j := 0; j < b.cols; j++ {
+            entry := product.data[i * b.cols + j]
+
+            for k := 0; k < a.cols; k++ {
+                entry.Add(entry, term.Mul(a.data[i * a.cols + k], b.data[k * b.cols + j]))
+            }
+        }
+    }
+
+    return product
+}
+
+/*
+    Reports exactly whether the symmetric matrix a is positive definite. The LDLᵀ decomposition is calculated by Gaussian elimination
+    without pivoting, whose pivots are the entries of D. A symmetric matrix is positive definite if and only if all pivots are positive
+    (which is equivalent to Sylvester's criterion, as the k-th leading principal minor is the product of the first k pivots).
+*/
+func RatIsPositiveDefinite(a *RatDense) bool {
+    if a.rows != a.cols {
+        panic(mat.ErrSquare)
+    }
+
+    n := a.rows
+    work := NewRatDense(n, n, a.data)
+    factor := new(big.Rat)
+    term := new(big.Rat)
+
+    for k := 0; k < n; k++ {
+        pivot := work.data[k * n + k]
+
+        if pivot.Sign() <= 0 {
+            return false
+        }
+
+        for i := k + 1; i < n; i++ {
+            factor.Quo(work.data[i * n + k], pivot)
+
+            for j := k + 1; j < n; j++ {
+                work.data[i * n + j].Sub(work.data[i * n + j], term.Mul(factor, work.data[k * n + j]))
+            }
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,92 @@
+package bigmat
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/big"
+    "testing"
+)
+
+func rats(values ...string) []*big.Rat {
+    data := make([]*big.Rat, len(values))
+
+    for i, v := range values {
+        data[i], _ = new(big.Rat).SetString(v)
+    }
+
+    return data
+}
+
+func TestRatArithmetic(t *testing.T) {
+    a := NewRatDense(2, 2, rats("1/10", "1/3", "0", "2/7"))
+    square := RatMul(a, a.T())
+    expected := NewRatDense(2, 2, rats("109/900", "2/21", "2/21", "4/49"))
+
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            if square.At(i, j).Cmp(expected.At(i, j)) != 0 {
+                t.Errorf("Wrong entry (%d, %d), got: %v, want: %v", i, j, square.At(i, j), expected.At(i, j))
+            }
+        }
+    }
+
+    diff := RatSub(RatIdentity(2), square)
+
+    if diff.At(0, 0).Cmp(big.NewRat(791, 900)) != 0 || diff.At(0, 1).Cmp(big.NewRat(-2, 21)) != 0 {
+        t.Errorf("Wrong difference, got: %v, %v", diff.At(0, 0), diff.At(0, 1))
+    }
+}
+
+func TestNewRatFromMatrix(t *testing.T) {
+    d, err := NewRatFromMatrix(mat.NewDense(1, 2, []float64{0.5, 0.1}))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if d.At(0, 0).Cmp(big.NewRat(1, 2)) != 0 || d.At(0, 1).Cmp(big.NewRat(1, 10)) == 0 {
+        t.Errorf("Conversion is not exact, got: %v, %v", d.At(0, 0), d.At(0, 1))
+    }
+
+    if f, _ := d.At(0, 1).Float64(); f != 0.1 {
+        t.Errorf("Conversion does not round back, got: %v", f)
+    }
+
+    if _, err := NewRatFromMatrix(mat.NewDense(1, 1, []float64{math.NaN()})); err == nil {
+        t.Errorf("Expected error for NaN")
+    }
+}
+
+func TestRatDense(t *testing.T) {
+    d := NewRatDense(1, 1, rats("1/3"))
+    rounded, _ := d.Dense(53).At(0, 0).Float64()
+
+    if rounded != 1.0 / 3 {
+        t.Errorf("Wrong rounding, got: %v", rounded)
+    }
+}
+
+func TestRatIsPositiveDefinite(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *RatDense
+        expected bool
+    }{
+        {desc: "positive definite", value: NewRatDense(2, 2, rats("2", "1", "1", "2")), expected: true},
+        // 1 + 10^-40 is 1 in float64, so this matrix is singular in floating point arithmetic
+        {desc: "barely positive definite", value: NewRatDense(2, 2, rats("1", "1", "1", "1.0000000000000000000000000000000000000001")), expected: true},
+        {desc: "singular", value: NewRatDense(2, 2, rats("1/3", "1/3", "1/3", "1/3")), expected: false},
+        {desc: "indefinite", value: NewRatDense(2, 2, rats("1", "2", "2", "1")), expected: false},
+        {desc: "zero pivot", value: NewRatDense(2, 2, rats("0", "1", "1", "0")), expected: false},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            if got := RatIsPositiveDefinite(table.value); got != table.expected {
+                t.Errorf("RatIsPositiveDefinite was incorrect, got: %t, want: %t", got, table.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,200 @@
+/*
+    Package blockmatrix builds dense matrices from blocks and gives access to the blocks of a partitioned matrix.
+*/
+package blockmatrix
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+// A dense matrix that remembers its partition into blocks. The blocks of one block row have the same number of rows,
+// the blocks of one block column have the same number of columns.
+type BlockMatrix struct {
+    dense *mat.Dense
+    rows []int
+    cols []int
+    // offsets of the block rows and columns, with the dimension of the matrix as last entry
+    rowOffsets []int
+    colOffsets []int
+}
+
+func offsets(sizes []int) []int {
+    o := make([]int, len(sizes) + 1)
+
+    for i, size := range sizes {
+        o[i + 1] = o[i] + size
+    }
+
+    return o
+}
+
+func validatePartition(sizes []int, dim int, name string) error {
+    sum := 0
+
+    for i, size := range sizes {
+        if size <= 0 {
+            return fmt.Errorf("Unexpected size of block %s %d: %d (Expecting a positive size)", name, i, size)
+        }
+        sum += size
+    }
+
+    if sum != dim {
+        return fmt.Errorf("Unexpected partition of %s: sizes sum up to %d (Expecting %d)", name, sum, dim)
+    }
+
+    return nil
+}
+
+// Returns a block matrix that shares the storage of d and is partitioned into blocks with the given numbers of rows and columns
+func NewFromDense(d *mat.Dense, rows, cols []int) (*BlockMatrix, error) {
+    if err := guard.CheckMatrix(d); err != nil {
+        return nil, err
+    }
+
+    r, c := d.Dims()
+
+    if err := validatePartition(rows, r, "rows"); err != nil {
+        return nil, err
+    }
+
+    if err := validatePartition(cols, c, "columns"); err != nil {
+        return nil, err
+    }
+
+    return &BlockMatrix{
+        dense: d,
+        rows: append([]int(nil), rows...),
+        cols: append([]int(nil), cols...),
+        rowOffsets: offsets(rows),
+        colOffsets: offsets(cols),
+    }, nil
+}
+
+func uniformPartition(blocks, size int) []int {
+    sizes := make([]int, blocks)
+
+    for i := range sizes {
+        sizes[i] = size
+    }
+
+    return sizes
+}
+
+// Returns a block matrix built from rows by NewBlockMatrixFromSquares, which remembers the partition into the square blocks
+func New(rows [][]mat.Matrix) (*BlockMatrix, error) {
+    d, err := NewBlockMatrixFromSquares(rows)
+
+    if err != nil {
+        return nil, err
+    }
+
+    d0, _ := rows[0][0].Dims()
+    partition := uniformPartition(len(rows), d0)
+
+    return NewFromDense(d, partition, partition)
+}
+
+// Returns a block matrix built from rows of rectangular blocks by NewBlockMatrix, which remembers the partition into the blocks
+func NewRectangular(rows [][]mat.Matrix) (*BlockMatrix, error) {
+    d, err := NewBlockMatrix(rows)
+
+    if err != nil {
+        return nil, err
+    }
+
+    partitionRows := make([]int, len(rows))
+    partitionCols := make([]int, len(rows[0]))
+
+    for i := range rows {
+        partitionRows[i], _ = rows[i][0].Dims()
+    }
+
+    for j := range rows[0] {
+        _, partitionCols[j] = rows[0][j].Dims()
+    }
+
+    return NewFromDense(d, partitionRows, partitionCols)
+}
+
+// Returns a block matrix that shares the storage of d and is partitioned into square blocks of dimension blockSize
+func NewFromDenseSquares(d *mat.Dense, blockSize int) (*BlockMatrix, error) {
+    if err := guard.CheckMatrix(d); err != nil {
+        return nil, err
+    }
+
+    r, c := d.Dims()
+
+    if blockSize <= 0 || r % blockSize != 0 || c % blockSize != 0 {
+        return nil, fmt.Errorf("Unexpected block size %d for dimension (%d, %d)", blockSize, r, c)
+    }
+
+    return NewFromDense(d, uniformPartition(r / blockSize, blockSize), uniformPartition(c / blockSize, blockSize))
+}
+
+func (b *BlockMatrix) Dims() (r, c int) {
+    return b.dense.Dims()
+}
+
+func (b *BlockMatrix) At(i, j int) float64 {
+    return b.dense.At(i, j)
+}
+
+func (b *BlockMatrix) T() mat.Matrix {
+    return mat.Transpose{Matrix: b}
+}
+
+// Returns the number of rows of each block row and the number of columns of each block column
+func (b *BlockMatrix) Partition() (rows, cols []int) {
+    return append([]int(nil), b.rows...), append([]int(nil), b.cols...)
+}
+
+// Returns the number of block rows and block columns
+func (b *BlockMatrix) BlockDims() (r, c int) {
+    return len(b.rows), len(b.cols)
+}
+
+// returns an error, if (i, j) is not the index of a block
+func (b *BlockMatrix) checkBlockIndex(i, j int) error {
+    if i < 0 || i >= len(b.rows) || j < 0 || j >= len(b.cols) {
+        return fmt.Errorf("Unexpected block index (%d, %d) (Expecting less than (%d, %d))", i, j, len(b.rows), len(b.cols))
+    }
+
+    return nil
+}
+
+// Returns a view of block (i, j), changes to the view are reflected in the block matrix and vice versa, or an error, if (i, j) is out of range
+func (b *BlockMatrix) Block(i, j int) (*mat.Dense, error) {
+    if err := b.checkBlockIndex(i, j); err != nil {
+        return nil, err
+    }
+
+    return b.dense.Slice(b.rowOffsets[i], b.rowOffsets[i + 1], b.colOffsets[j], b.colOffsets[j + 1]).(*mat.Dense), nil
+}
+
+// Copies m into block (i, j) or returns an error, if m is nil, (i, j) is out of range or m does not have the dimension of the block
+func (b *BlockMatrix) SetBlock(i, j int, m mat.Matrix) (err error) {
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(m); err != nil {
+        return err
+    }
+
+    if err := b.checkBlockIndex(i, j); err != nil {
+        return err
+    }
+
+    if r, c := m.Dims(); r != b.rows[i] || c != b.cols[j] {
+        return fmt.Errorf("Unexpected dimension: (%d, %d) for block (%d, %d) (Expecting (%d, %d))", r, c, i, j, b.rows[i], b.cols[j])
+    }
+
+    block, _ := b.Block(i, j)
+    block.Copy(m)
+    return nil
+}
+
+// Returns the block matrix as *mat.Dense, which shares the storage of the block matrix
+func (b *BlockMatrix) Dense() *mat.Dense {
+    return b.dense
+}
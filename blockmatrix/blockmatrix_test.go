@@ -0,0 +1,224 @@
+package blockmatrix
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+// returns block (i, j) of b and fails the test, if Block returns an error
+func mustBlock(t *testing.T, b *BlockMatrix, i, j int) *mat.Dense {
+    t.Helper()
+    block, err := b.Block(i, j)
+
+    if err != nil {
+        t.Fatalf("Block returned unexpected error: %v", err)
+    }
+
+    return block
+}
+
+func TestNew(t *testing.T) {
+    b, err := New(createRows(3, 2))
+
+    if err != nil {
+        t.Fatalf("New returned unexpected error: %v", err)
+    }
+
+    expected, _ := NewBlockMatrixFromSquares(createRows(3, 2))
+
+    if !mat.Equal(b, expected) || !mat.Equal(b.Dense(), expected) {
+        t.Errorf("New returned wrong value, got: %v, want: %v.", b.Dense(), expected)
+    }
+
+    rows, cols := b.Partition()
+
+    if !reflect.DeepEqual(rows, []int{2, 2, 2}) || !reflect.DeepEqual(cols, []int{2, 2, 2}) {
+        t.Errorf("Wrong partition, got: %v, %v", rows, cols)
+    }
+
+    if r, c := b.BlockDims(); r != 3 || c != 3 {
+        t.Errorf("Wrong block dimension, got: (%d, %d), want: (3, 3)", r, c)
+    }
+
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            value := float64(3 * i + j)
+            if !mat.Equal(mustBlock(t, b, i, j), mat.NewDense(2, 2, []float64{value, value, value, value})) {
+                t.Errorf("Wrong block (%d, %d), got: %v", i, j, mustBlock(t, b, i, j))
+            }
+        }
+    }
+}
+
+func TestNewError(t *testing.T) {
+    rows := [][]mat.Matrix{
+        []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 2, nil),},
+        []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil),},
+    }
+    b, err := New(rows)
+    expectedErr := fmt.Errorf("Unexpected dimension: (1, 2) in row 0, col 1 (Expecting (1, 1))")
+
+    if !reflect.DeepEqual(err, expectedErr) || b != nil {
+        t.Errorf("New returned wrong value, got: %v, %v, want: nil, %v", b, err, expectedErr)
+    }
+}
+
+func TestNewFromDense(t *testing.T) {
+    tables := []struct {
+        desc string
+        dense *mat.Dense
+        rows []int
+        cols []int
+        err error
+    }{
+        {desc: "square blocks", dense: mat.NewDense(4, 4, nil), rows: []int{2, 2}, cols: []int{2, 2}, err: nil},
+        {desc: "rectangular blocks", dense: mat.NewDense(5, 5, nil), rows: []int{2, 3}, cols: []int{3, 2}, err: nil},
+        {desc: "validates the rows", dense: mat.NewDense(4, 4, nil), rows: []int{2, 1}, cols: []int{2, 2}, err: fmt.Errorf("Unexpected partition of rows: sizes sum up to 3 (Expecting 4)")},
+        {desc: "validates the columns", dense: mat.NewDense(4, 4, nil), rows: []int{2, 2}, cols: []int{3, 2}, err: fmt.Errorf("Unexpected partition of columns: sizes sum up to 5 (Expecting 4)")},
+        {desc: "validates the matrix", dense: &mat.Dense{}, rows: []int{}, cols: []int{}, err: ErrEmptyMatrix},
+        {desc: "validates the sizes", dense: mat.NewDense(4, 4, nil), rows: []int{4, 0}, cols: []int{2, 2}, err: fmt.Errorf("Unexpected size of block rows 1: 0 (Expecting a positive size)")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            b, err := NewFromDense(table.dense, table.rows, table.cols)
+
+            if !reflect.DeepEqual(err, table.err) {
+                t.Errorf("NewFromDense returned wrong error, got: %v, want: %v", err, table.err)
+            }
+
+            if err == nil && b.Dense() != table.dense {
+                t.Errorf("NewFromDense does not share the storage")
+            }
+        })
+    }
+}
+
+func TestNewFromDenseSquares(t *testing.T) {
+    b, err := NewFromDenseSquares(mat.NewDense(6, 4, nil), 2)
+
+    if err != nil {
+        t.Fatalf("NewFromDenseSquares returned unexpected error: %v", err)
+    }
+
+    if r, c := b.BlockDims(); r != 3 || c != 2 {
+        t.Errorf("Wrong block dimension, got: (%d, %d), want: (3, 2)", r, c)
+    }
+
+    _, err = NewFromDenseSquares(mat.NewDense(6, 4, nil), 4)
+    expectedErr := fmt.Errorf("Unexpected block size 4 for dimension (6, 4)")
+
+    if !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("NewFromDenseSquares returned wrong error, got: %v, want: %v", err, expectedErr)
+    }
+}
+
+func TestBlockIsView(t *testing.T) {
+    b, _ := NewFromDense(mat.NewDense(3, 3, nil), []int{1, 2}, []int{2, 1})
+
+    mustBlock(t, b, 1, 0).Set(1, 1, 5)
+
+    if b.At(2, 1) != 5 {
+        t.Errorf("Change of block is not reflected in block matrix, got: %v", mat.Formatted(b))
+    }
+
+    b.Dense().Set(0, 2, 7)
+
+    if mustBlock(t, b, 0, 1).At(0, 0) != 7 {
+        t.Errorf("Change of block matrix is not reflected in block, got: %v", mustBlock(t, b, 0, 1))
+    }
+}
+
+func TestBlockIndexOutOfRange(t *testing.T) {
+    tables := []struct {
+        desc string
+        i, j int
+        err error
+    }{
+        {desc: "row", i: 2, j: 0, err: fmt.Errorf("Unexpected block index (2, 0) (Expecting less than (2, 2))")},
+        {desc: "col", i: 0, j: 2, err: fmt.Errorf("Unexpected block index (0, 2) (Expecting less than (2, 2))")},
+        {desc: "negative row", i: -1, j: 0, err: fmt.Errorf("Unexpected block index (-1, 0) (Expecting less than (2, 2))")},
+        {desc: "negative col", i: 1, j: -1, err: fmt.Errorf("Unexpected block index (1, -1) (Expecting less than (2, 2))")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            b, _ := NewFromDense(mat.NewDense(3, 3, nil), []int{1, 2}, []int{2, 1})
+
+            if block, err := b.Block(table.i, table.j); !reflect.DeepEqual(err, table.err) || block != nil {
+                t.Errorf("Block returned wrong value, got: %v, %v, want: nil, %v", block, err, table.err)
+            }
+        })
+    }
+}
+
+func TestSetBlock(t *testing.T) {
+    tables := []struct {
+        desc string
+        i, j int
+        m mat.Matrix
+        expected *mat.Dense
+        err error
+    }{
+        {desc: "sets a block", i: 1, j: 0, m: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), expected: mat.NewDense(3, 3, []float64{0, 0, 0, 1, 2, 0, 3, 4, 0}), err: nil},
+        {desc: "accepts any matrix type", i: 0, j: 1, m: mat.NewDiagDense(1, []float64{9}), expected: mat.NewDense(3, 3, []float64{0, 0, 9, 0, 0, 0, 0, 0, 0}), err: nil},
+        {desc: "validates the dimension", i: 0, j: 0, m: mat.NewDense(2, 2, nil), expected: mat.NewDense(3, 3, nil), err: fmt.Errorf("Unexpected dimension: (2, 2) for block (0, 0) (Expecting (1, 2))")},
+        {desc: "validates the matrix", i: 0, j: 0, m: nil, expected: mat.NewDense(3, 3, nil), err: ErrNilMatrix},
+        {desc: "validates the index", i: 0, j: 2, m: mat.NewDense(1, 1, nil), expected: mat.NewDense(3, 3, nil), err: fmt.Errorf("Unexpected block index (0, 2) (Expecting less than (2, 2))")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            b, _ := NewFromDense(mat.NewDense(3, 3, nil), []int{1, 2}, []int{2, 1})
+
+            err := b.SetBlock(table.i, table.j, table.m)
+
+            if !reflect.DeepEqual(err, table.err) {
+                t.Errorf("SetBlock returned wrong error, got: %v, want: %v", err, table.err)
+            }
+
+            if !mat.Equal(b, table.expected) {
+                t.Errorf("SetBlock wrote wrong value, got: %v, want: %v", mat.Formatted(b), mat.Formatted(table.expected))
+            }
+        })
+    }
+}
+
+func TestPartitionIsCopy(t *testing.T) {
+    b, _ := NewFromDense(mat.NewDense(3, 3, nil), []int{1, 2}, []int{2, 1})
+    rows, _ := b.Partition()
+    rows[0] = 3
+
+    if rows, _ := b.Partition(); rows[0] != 1 {
+        t.Errorf("Partition can be changed from outside, got: %v", rows)
+    }
+}
+
+func TestNewRectangular(t *testing.T) {
+    b, err := NewRectangular([][]mat.Matrix{
+        []mat.Matrix{mat.NewDense(1, 2, []float64{1,2,}), mat.NewDense(1, 1, []float64{3,}),},
+        []mat.Matrix{mat.NewDense(2, 2, []float64{4,5,7,8,}), mat.NewDense(2, 1, []float64{6,9,}),},
+    })
+
+    if err != nil {
+        t.Fatalf("NewRectangular returned unexpected error: %v", err)
+    }
+
+    rows, cols := b.Partition()
+
+    if !reflect.DeepEqual(rows, []int{1, 2}) || !reflect.DeepEqual(cols, []int{2, 1}) {
+        t.Errorf("Wrong partition, got: %v, %v", rows, cols)
+    }
+
+    if !mat.Equal(mustBlock(t, b, 1, 1), mat.NewDense(2, 1, []float64{6,9,})) {
+        t.Errorf("Wrong block (1, 1), got: %v", mustBlock(t, b, 1, 1))
+    }
+}
@@ -0,0 +1,192 @@
+package blockmatrix
+
+import (
+    "errors"
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+var (
+    // returned for a nil block or destination
+    ErrNilMatrix = guard.ErrNilMatrix
+    // returned for a block or matrix without rows or columns
+    ErrEmptyMatrix = guard.ErrEmptyMatrix
+    // returned if there are no rows of blocks
+    ErrNoBlocks = errors.New("Block matrix has no blocks")
+)
+
+// returned instead of a panic of gonum or of a mat.Matrix implementation passed as block
+type PanicError = guard.PanicError
+
+func validateDims(rows [][]mat.Matrix) (bool, error) {
+    n0 := len(rows)
+
+    if n0 == 0 {
+        return false, ErrNoBlocks
+    }
+
+    for i, row := range rows {
+        n := len(row)
+
+        if n != n0 {
+            return false, fmt.Errorf("Unexpected length of row: %d has length %d (Expecting %d)", i, n, n0)
+        }
+
+        for j, matrix := range row {
+            if err := guard.CheckMatrix(matrix); err != nil {
+                return false, fmt.Errorf("Unexpected block in row %d, col %d: %w", i, j, err)
+            }
+        }
+    }
+
+    d0, _ := rows[0][0].Dims()
+
+    for i, row := range rows {
+        for j, matrix := range row {
+            d1, d2 := matrix.Dims()
+
+            if d1 != d0 || d2 != d0 {
+                return false, fmt.Errorf("Unexpected dimension: (%d, %d) in row %d, col %d (Expecting (%d, %d))", d1, d2, i, j, d0, d0)
+            }
+        }
+    }
+
+    return true, nil
+}
+
+func NewBlockMatrixFromSquares(rows [][]mat.Matrix) (*mat.Dense, error) {
+    var dst mat.Dense
+
+    if err := BlockMatrixFromSquaresTo(&dst, rows); err != nil {
+        return nil, err
+    }
+
+    return &dst, nil
+}
+
+/*
+    Writes the block matrix built from rows into dst.
+    An empty dst is resized to the dimension of the block matrix, a non-empty dst must already have that dimension.
+    The storage of a non-empty dst is reused, so no new matrix is allocated.
+    A nil dst results in ErrNilMatrix.
+*/
+func BlockMatrixFromSquaresTo(dst *mat.Dense, rows [][]mat.Matrix) (err error) {
+    defer guard.Recover(&err)
+
+    if dst == nil {
+        return ErrNilMatrix
+    }
+
+    ok, err := validateDims(rows)
+
+    if !ok {
+       return err
+    }
+
+    d0, _ := rows[0][0].Dims()
+    d := d0 * len(rows)
+
+    if dst.IsEmpty() {
+        dst.ReuseAs(d, d)
+    } else if r, c := dst.Dims(); r != d || c != d {
+        return fmt.Errorf("Unexpected dimension of destination: (%d, %d) (Expecting (%d, %d))", r, c, d, d)
+    }
+
+    o := offsets(uniformPartition(len(rows), d0))
+    copyBlocks(dst, rows, o, o)
+    return nil
+}
+
+// copies each block of rows into dst at the given offsets of its block row and block column
+func copyBlocks(dst *mat.Dense, rows [][]mat.Matrix, rowOffsets, colOffsets []int) {
+    for i, row := range rows {
+        for j, matrix := range row {
+            block := dst.Slice(rowOffsets[i], rowOffsets[i + 1], colOffsets[j], colOffsets[j + 1]).(*mat.Dense)
+            block.Copy(matrix)
+        }
+    }
+}
+
+// returns the number of rows of each block row and the number of columns of each block column, which all blocks of the row or column need to share
+func validateRectangularDims(rows [][]mat.Matrix) (rowSizes, colSizes []int, err error) {
+    if len(rows) == 0 {
+        return nil, nil, ErrNoBlocks
+    }
+
+    n0 := len(rows[0])
+
+    for i, row := range rows {
+        if n := len(row); n != n0 || n == 0 {
+            return nil, nil, fmt.Errorf("Unexpected length of row: %d has length %d (Expecting %d)", i, n, n0)
+        }
+
+        for j, matrix := range row {
+            if err := guard.CheckMatrix(matrix); err != nil {
+                return nil, nil, fmt.Errorf("Unexpected block in row %d, col %d: %w", i, j, err)
+            }
+        }
+    }
+
+    rowSizes = make([]int, len(rows))
+    colSizes = make([]int, n0)
+
+    for i := range rows {
+        rowSizes[i], _ = rows[i][0].Dims()
+    }
+
+    for j := range rows[0] {
+        _, colSizes[j] = rows[0][j].Dims()
+    }
+
+    for i, row := range rows {
+        for j, matrix := range row {
+            if d1, d2 := matrix.Dims(); d1 != rowSizes[i] || d2 != colSizes[j] {
+                return nil, nil, fmt.Errorf("Unexpected dimension: (%d, %d) in row %d, col %d (Expecting (%d, %d))", d1, d2, i, j, rowSizes[i], colSizes[j])
+            }
+        }
+    }
+
+    return rowSizes, colSizes, nil
+}
+
+// same as NewBlockMatrixFromSquares for rectangular blocks, see BlockMatrixTo
+func NewBlockMatrix(rows [][]mat.Matrix) (*mat.Dense, error) {
+    var dst mat.Dense
+
+    if err := BlockMatrixTo(&dst, rows); err != nil {
+        return nil, err
+    }
+
+    return &dst, nil
+}
+
+/*
+    Same as BlockMatrixFromSquaresTo for rectangular blocks: all blocks of a block row need to have the same number of rows,
+    all blocks of a block column the same number of columns, and every block row needs to have the same number of blocks.
+*/
+func BlockMatrixTo(dst *mat.Dense, rows [][]mat.Matrix) (err error) {
+    defer guard.Recover(&err)
+
+    if dst == nil {
+        return ErrNilMatrix
+    }
+
+    rowSizes, colSizes, err := validateRectangularDims(rows)
+
+    if err != nil {
+        return err
+    }
+
+    rowOffsets, colOffsets := offsets(rowSizes), offsets(colSizes)
+    r, c := rowOffsets[len(rowSizes)], colOffsets[len(colSizes)]
+
+    if dst.IsEmpty() {
+        dst.ReuseAs(r, c)
+    } else if dr, dc := dst.Dims(); dr != r || dc != c {
+        return fmt.Errorf("Unexpected dimension of destination: (%d, %d) (Expecting (%d, %d))", dr, dc, r, c)
+    }
+
+    copyBlocks(dst, rows, rowOffsets, colOffsets)
+    return nil
+}
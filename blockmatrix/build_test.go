@@ -0,0 +1,307 @@
+package blockmatrix
+
+import (
+    "errors"
+    "gonum.org/v1/gonum/mat"
+    "fmt"
+    "math"
+    "reflect"
+    "testing"
+)
+
+func createRows(n, dim int) (rows [][]mat.Matrix) {
+    rows = make([][]mat.Matrix, n)
+
+    for i := range rows {
+        row := make([]mat.Matrix, n)
+
+        for j := range row {
+        value := float64(n * i + j)
+            data := make([]float64, int(math.Pow(float64(dim), 2)))
+            for k, _ := range data {
+                data[k] = value
+            }
+            row[j] = mat.NewDense(dim, dim, data)
+        }
+
+        rows[i] = row
+    }
+    return
+}
+
+func TestNewBlockMatrixFromSquares(t *testing.T) {
+    tables := []struct {
+        desc string
+        rows [][]mat.Matrix
+        expected *mat.Dense
+        err error
+    }{
+        {
+            rows: createRows(2, 2),
+            expected: mat.NewDense(4, 4, []float64{0,0,1,1,0,0,1,1,2,2,3,3,2,2,3,3,}),
+            desc: "returns correct matrix for 4 2x2 blocks",
+            err: nil,
+        },
+        {
+            rows: createRows(3, 2),
+            expected: mat.NewDense(6, 6, []float64{0,0,1,1,2,2,0,0,1,1,2,2,3,3,4,4,5,5,3,3,4,4,5,5,6,6,7,7,8,8,6,6,7,7,8,8,}),
+            desc: "returns correct matrix for 9 2x2 blocks",
+            err: nil,
+        },
+        {
+            rows: createRows(2, 3),
+            expected: mat.NewDense(6, 6, []float64{0,0,0,1,1,1,0,0,0,1,1,1,0,0,0,1,1,1,2,2,2,3,3,3,2,2,2,3,3,3,2,2,2,3,3,3,}),
+            desc: "returns correct matrix for 4 3x3 blocks",
+            err: nil,
+        },
+        {
+            rows: createRows(5, 1),
+            expected: mat.NewDense(5, 5, []float64{0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,}),
+            desc: "returns correct matrix for 25 1x1 blocks",
+            err: nil,
+        },
+        {
+            rows: createRows(1, 5),
+            expected: mat.NewDense(5, 5, nil),
+            desc: "returns correct matrix for 1 5x5 blocks",
+            err: nil,
+        },
+        {
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewSymDense(2, []float64{1,2,2,3}), mat.NewTriDense(2, mat.Upper, []float64{4,5,0,6}),},
+                []mat.Matrix{mat.NewDiagDense(2, []float64{7,8}), mat.NewDense(2, 2, []float64{1,2,3,4}).T(),},
+            },
+            expected: mat.NewDense(4, 4, []float64{1,2,4,5,2,3,0,6,7,0,1,3,0,8,2,4,}),
+            desc: "accepts blocks of any matrix type",
+            err: nil,
+        },
+        {
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 2, nil),},
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil),},
+            },
+            expected: nil,
+            desc: "validates all matrices have the same amount of columns",
+            err: fmt.Errorf("Unexpected dimension: (1, 2) in row 0, col 1 (Expecting (1, 1))"),
+        },
+        {
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(2, 1, nil),},
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil),},
+            },
+            expected: nil,
+            desc: "validates all matrices have the same amount of rows",
+            err: fmt.Errorf("Unexpected dimension: (2, 1) in row 0, col 1 (Expecting (1, 1))"),
+        },
+        {
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil),},
+                []mat.Matrix{mat.NewDense(1, 1, nil),mat.NewDense(1, 1, nil),mat.NewDense(1, 1, nil),},
+            },
+            expected: nil,
+            desc: "validates length of each row is the same",
+            err: fmt.Errorf("Unexpected length of row: 1 has length 3 (Expecting 2)"),
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            blockMatrix, err := NewBlockMatrixFromSquares(table.rows)
+
+            if !reflect.DeepEqual(err, table.err) {
+                t.Errorf("NewBlockMatrix returned wrong value for err, got: %v, want: %v.", err, table.err)
+            }
+
+            if (blockMatrix != nil && table.expected == nil || blockMatrix == nil && table.expected != nil) {
+                t.Errorf("NewBlockMatrix returned wrong value, got: %v, want: %v.", blockMatrix, table.expected)
+            }
+
+            if (blockMatrix != nil && table.expected != nil && !mat.Equal(blockMatrix, table.expected)) {
+                t.Errorf("NewBlockMatrix returned wrong value, got: %v, want: %v.", blockMatrix, table.expected)
+            }
+        })
+    }
+}
+
+func TestBlockMatrixFromSquaresTo(t *testing.T) {
+    tables := []struct {
+        desc string
+        dst *mat.Dense
+        rows [][]mat.Matrix
+        expected *mat.Dense
+        err error
+    }{
+        {
+            dst: &mat.Dense{},
+            rows: createRows(2, 2),
+            expected: mat.NewDense(4, 4, []float64{0,0,1,1,0,0,1,1,2,2,3,3,2,2,3,3,}),
+            desc: "resizes an empty destination",
+            err: nil,
+        },
+        {
+            dst: mat.NewDense(4, 4, []float64{9,9,9,9,9,9,9,9,9,9,9,9,9,9,9,9,}),
+            rows: createRows(2, 2),
+            expected: mat.NewDense(4, 4, []float64{0,0,1,1,0,0,1,1,2,2,3,3,2,2,3,3,}),
+            desc: "overwrites a destination with matching dimension",
+            err: nil,
+        },
+        {
+            dst: mat.NewDense(3, 4, nil),
+            rows: createRows(2, 2),
+            expected: mat.NewDense(3, 4, nil),
+            desc: "validates the dimension of the destination",
+            err: fmt.Errorf("Unexpected dimension of destination: (3, 4) (Expecting (4, 4))"),
+        },
+        {
+            dst: mat.NewDense(2, 2, nil),
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 2, nil),},
+                []mat.Matrix{mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil),},
+            },
+            expected: mat.NewDense(2, 2, nil),
+            desc: "validates the blocks",
+            err: fmt.Errorf("Unexpected dimension: (1, 2) in row 0, col 1 (Expecting (1, 1))"),
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            err := BlockMatrixFromSquaresTo(table.dst, table.rows)
+
+            if !reflect.DeepEqual(err, table.err) {
+                t.Errorf("BlockMatrixFromSquaresTo returned wrong value for err, got: %v, want: %v.", err, table.err)
+            }
+
+            if !mat.Equal(table.dst, table.expected) {
+                t.Errorf("BlockMatrixFromSquaresTo wrote wrong value, got: %v, want: %v.", table.dst, table.expected)
+            }
+        })
+    }
+}
+
+func TestBlockMatrixFromSquaresToReusesStorage(t *testing.T) {
+    dst := mat.NewDense(4, 4, nil)
+    raw := dst.RawMatrix().Data
+
+    if err := BlockMatrixFromSquaresTo(dst, createRows(2, 2)); err != nil {
+        t.Fatalf("BlockMatrixFromSquaresTo returned unexpected error: %v", err)
+    }
+
+    if &dst.RawMatrix().Data[0] != &raw[0] {
+        t.Errorf("BlockMatrixFromSquaresTo did not reuse the storage of the destination")
+    }
+}
+
+func TestNewBlockMatrixFromSquaresInvalidInput(t *testing.T) {
+    var nilDense *mat.Dense
+
+    tables := []struct {
+        desc string
+        rows [][]mat.Matrix
+        expected error
+    }{
+        {desc: "nil rows", rows: nil, expected: ErrNoBlocks},
+        {desc: "no rows", rows: [][]mat.Matrix{}, expected: ErrNoBlocks},
+        {desc: "empty row", rows: [][]mat.Matrix{[]mat.Matrix{}}, expected: fmt.Errorf("Unexpected length of row: 0 has length 0 (Expecting 1)")},
+        {desc: "nil block", rows: [][]mat.Matrix{[]mat.Matrix{nil}}, expected: ErrNilMatrix},
+        {desc: "typed nil block", rows: [][]mat.Matrix{[]mat.Matrix{nilDense}}, expected: ErrNilMatrix},
+        {desc: "empty block", rows: [][]mat.Matrix{[]mat.Matrix{&mat.Dense{}}}, expected: ErrEmptyMatrix},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            value, err := NewBlockMatrixFromSquares(table.rows)
+
+            if value != nil || !(errors.Is(err, table.expected) || reflect.DeepEqual(err, table.expected)) {
+                t.Errorf("NewBlockMatrixFromSquares returned wrong value, got: %v, %v, want: nil, %v", value, err, table.expected)
+            }
+
+            if b, err := New(table.rows); b != nil || !(errors.Is(err, table.expected) || reflect.DeepEqual(err, table.expected)) {
+                t.Errorf("New returned wrong value, got: %v, %v, want: nil, %v", b, err, table.expected)
+            }
+        })
+    }
+}
+
+func TestBlockMatrixFromSquaresToNilDestination(t *testing.T) {
+    if err := BlockMatrixFromSquaresTo(nil, createRows(2, 2)); err != ErrNilMatrix {
+        t.Errorf("Wrong error, got: %v, want: %v", err, ErrNilMatrix)
+    }
+}
+
+func TestNewBlockMatrix(t *testing.T) {
+    rows := [][]mat.Matrix{
+        []mat.Matrix{mat.NewDense(1, 2, []float64{1,2,}), mat.NewDense(1, 1, []float64{3,}),},
+        []mat.Matrix{mat.NewDense(2, 2, []float64{4,5,7,8,}), mat.NewDense(2, 1, []float64{6,9,}),},
+    }
+    expected := mat.NewDense(3, 3, []float64{1,2,3,4,5,6,7,8,9,})
+
+    value, err := NewBlockMatrix(rows)
+
+    if err != nil || !mat.Equal(value, expected) {
+        t.Errorf("NewBlockMatrix returned wrong value, got: %v, %v, want: %v, nil", value, err, expected)
+    }
+
+    dst := mat.NewDense(3, 3, nil)
+
+    if err := BlockMatrixTo(dst, rows); err != nil || !mat.Equal(dst, expected) {
+        t.Errorf("BlockMatrixTo wrote wrong value, got: %v, %v, want: %v, nil", dst, err, expected)
+    }
+}
+
+func TestNewBlockMatrixInvalidInput(t *testing.T) {
+    tables := []struct {
+        desc string
+        dst *mat.Dense
+        rows [][]mat.Matrix
+        expected error
+    }{
+        {
+            desc: "validates the number of rows in a block row",
+            dst: &mat.Dense{},
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 2, nil), mat.NewDense(2, 1, nil),},
+            },
+            expected: fmt.Errorf("Unexpected dimension: (2, 1) in row 0, col 1 (Expecting (1, 1))"),
+        },
+        {
+            desc: "validates the number of columns in a block column",
+            dst: &mat.Dense{},
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 2, nil),},
+                []mat.Matrix{mat.NewDense(1, 1, nil),},
+            },
+            expected: fmt.Errorf("Unexpected dimension: (1, 1) in row 1, col 0 (Expecting (1, 2))"),
+        },
+        {
+            desc: "validates the dimension of the destination",
+            dst: mat.NewDense(2, 2, nil),
+            rows: [][]mat.Matrix{
+                []mat.Matrix{mat.NewDense(1, 2, nil),},
+            },
+            expected: fmt.Errorf("Unexpected dimension of destination: (2, 2) (Expecting (1, 2))"),
+        },
+        {desc: "nil destination", dst: nil, rows: createRows(2, 2), expected: ErrNilMatrix},
+        {desc: "no rows", dst: &mat.Dense{}, rows: nil, expected: ErrNoBlocks},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            err := BlockMatrixTo(table.dst, table.rows)
+
+            if !(errors.Is(err, table.expected) || reflect.DeepEqual(err, table.expected)) {
+                t.Errorf("BlockMatrixTo returned wrong value for err, got: %v, want: %v", err, table.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,98 @@
+//go:build go1.18
+// +build go1.18
+
+package blockmatrix
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "testing"
+)
+
+/*
+    Decodes rows of blocks from data: the first byte is the number of block rows, then every block row starts with its length,
+    followed by two bytes per block for its dimension. A dimension of 0 results in an empty block and a dimension of 255 in a nil block.
+    All numbers are limited to 4, so the blocks stay small.
+*/
+func fuzzRows(data []byte) [][]mat.Matrix {
+    next := func() int {
+        if len(data) == 0 {
+            return 0
+        }
+
+        b := int(data[0])
+        data = data[1:]
+        return b
+    }
+
+    block := func() mat.Matrix {
+        r, c := next(), next()
+
+        switch {
+        case r == 255 || c == 255:
+            return nil
+        case r % 5 == 0 || c % 5 == 0:
+            return &mat.Dense{}
+        }
+
+        return mat.NewDense(r % 5, c % 5, nil)
+    }
+
+    rows := make([][]mat.Matrix, next() % 5)
+
+    for i := range rows {
+        rows[i] = make([]mat.Matrix, next() % 5)
+
+        for j := range rows[i] {
+            rows[i][j] = block()
+        }
+    }
+
+    return rows
+}
+
+// Checks that NewBlockMatrixFromSquares returns either a block matrix or an error and never panics
+func FuzzNewBlockMatrixFromSquares(f *testing.F) {
+    f.Add([]byte{2, 2, 1, 1, 1, 1, 2, 1, 1, 1, 1})
+    f.Add([]byte{2, 2, 1, 1, 1, 1, 1, 1, 1})
+    f.Add([]byte{1, 1, 255, 1})
+    f.Add([]byte{1, 1, 0, 0})
+    f.Add([]byte{1, 0})
+    f.Add([]byte{})
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        d, err := NewBlockMatrixFromSquares(fuzzRows(data))
+
+        if (d == nil) == (err == nil) {
+            t.Errorf("NewBlockMatrixFromSquares returned unexpected value: %v, %v", d, err)
+        }
+    })
+}
+
+// Checks that Block and SetBlock return either a block or an error for every index and never panic
+func FuzzBlock(f *testing.F) {
+    f.Add([]byte{2, 2, 1, 1, 1, 1, 2, 1, 1, 1, 1}, 1, 0)
+    f.Add([]byte{2, 2, 1, 1, 1, 1, 2, 1, 1, 1, 1}, 2, 0)
+    f.Add([]byte{2, 2, 1, 1, 1, 1, 2, 1, 1, 1, 1}, 0, -1)
+
+    f.Fuzz(func(t *testing.T, data []byte, i, j int) {
+        b, err := NewRectangular(fuzzRows(data))
+
+        if err != nil {
+            return
+        }
+
+        block, err := b.Block(i, j)
+
+        if (block == nil) == (err == nil) {
+            t.Fatalf("Block returned unexpected value: %v, %v", block, err)
+        }
+
+        if block == nil {
+            block = mat.NewDense(1, 1, nil)
+        }
+
+        if setErr := b.SetBlock(i, j, block); (setErr == nil) != (err == nil) {
+            t.Errorf("SetBlock returned unexpected error: %v, Block returned: %v", setErr, err)
+        }
+    })
+}
@@ -0,0 +1,100 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+/*
+    Condition estimates how much the dilation U changes under a perturbation ΔT of t. To first order,
+    the defect operator D = sqrt(I - TᵀT) changes by ‖ΔD‖_F ≤ ‖T‖₂ ‖ΔT‖_F / sqrt(DefectGap), since the square root X of a matrix with smallest eigenvalue δ
+    fulfills X ΔX + ΔX X = ΔC with X ≥ sqrt(δ) I, and ΔC = -(ΔTᵀ T + Tᵀ ΔT). T appears twice in U and each defect operator once, which gives Absolute.
+*/
+type Condition struct {
+    // the largest singular value ‖T‖₂ of t
+    Norm float64
+    // the smallest singular value 1 - ‖T‖₂² of I - TTᵀ, which tends to 0 as ‖T‖₂ → 1
+    DefectGap float64
+    // bound of ‖ΔU‖_F / ‖ΔT‖_F = sqrt(2 (1 + ‖T‖₂² / DefectGap)), +Inf if t is not a contraction
+    Absolute float64
+    // bound of the relative change (‖ΔU‖_F / ‖U‖_F) / (‖ΔT‖_F / ‖T‖_F) = Absolute ‖T‖_F / ‖U‖_F
+    Relative float64
+}
+
+// returns a first order bound of ‖ΔU‖_F / ‖U‖_F for a relative perturbation ‖ΔT‖_F ≤ epsilon ‖T‖_F, e.g. for epsilon = 1e-16 the effect of rounding t
+func (c Condition) Perturbation(epsilon float64) float64 {
+    return c.Relative * epsilon
+}
+
+// returns the condition of the unitary n-dilation of t, which only depends on the singular values of t and the dimension of the dilation
+func conditionOf(t mat.Matrix, n int) Condition {
+    var svd mat.SVD
+    m, c := t.Dims()
+
+    if !svd.Factorize(t, mat.SVDNone) {
+        return Condition{Norm: math.NaN(), DefectGap: math.NaN(), Absolute: math.NaN(), Relative: math.NaN()}
+    }
+
+    values := svd.Values(nil)
+    norm := values[0]
+    frobenius := 0.0
+
+    for _, v := range values {
+        frobenius = math.Hypot(frobenius, v)
+    }
+
+    // (1 - σ)(1 + σ) does not cancel like 1 - σ² for σ close to 1
+    gap := (1 - norm) * (1 + norm)
+    absolute := math.Inf(1)
+
+    if gap > 0 {
+        absolute = math.Sqrt(2 * (1 + norm * norm / gap))
+    }
+
+    // U is unitary of dimension m + n c for an m times c matrix t, so ‖U‖_F = sqrt(m + n c)
+    return Condition{
+        Norm: norm,
+        DefectGap: gap,
+        Absolute: absolute,
+        Relative: absolute * frobenius / math.Sqrt(float64(m + n * c)),
+    }
+}
+
+/*
+    Returns the condition of the unitary n-dilation of t without calculating the dilation.
+    Invalid input results in the same errors as UnitaryNDilation, a t that is not a contraction in a Condition with infinite Absolute and Relative.
+*/
+func ConditionOf(t mat.Matrix, n int) (condition Condition, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return Condition{}, err
+    }
+
+    return conditionOf(t, n), nil
+}
+
+/*
+    Same as UnitaryNDilation, but returns the condition of the dilation as well, which estimates its sensitivity to perturbations of t.
+    With WithNearestContraction both are calculated for the nearest contraction of t.
+*/
+func UnitaryNDilationWithCondition(t mat.Matrix, n int, opts ...Option) (unitary *mat.Dense, condition Condition, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return nil, Condition{}, err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return nil, Condition{}, err
+    }
+
+    if unitary, err = o.dilate(t, n); err != nil {
+        return nil, Condition{}, err
+    }
+
+    return unitary, conditionOf(t, n), nil
+}
@@ -0,0 +1,141 @@
+package godilation
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func TestConditionOfScalar(t *testing.T) {
+    // U = [[t, D], [D, -t]] with D = sqrt(1 - t²) changes by sqrt(2 + 2 (t / D)²) |Δt|, so the bound is attained
+    condition, err := ConditionOf(mat.NewDense(1, 1, []float64{0.6}), 1)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := Condition{Norm: 0.6, DefectGap: 0.64, Absolute: math.Sqrt(3.125), Relative: 0.75}
+
+    if math.Abs(condition.Norm - expected.Norm) > 1e-15 || math.Abs(condition.DefectGap - expected.DefectGap) > 1e-15 ||
+        math.Abs(condition.Absolute - expected.Absolute) > 1e-14 || math.Abs(condition.Relative - expected.Relative) > 1e-14 {
+        t.Errorf("Wrong condition, got: %+v, want: %+v", condition, expected)
+    }
+}
+
+func TestConditionBoundsPerturbation(t *testing.T) {
+    tables := []struct {
+        desc string
+        norm float64
+    }{
+        {desc: "well-conditioned", norm: 0.5},
+        {desc: "norm close to 1", norm: 0.99},
+        {desc: "norm very close to 1", norm: 0.9999},
+    }
+
+    rnd := rand.New(rand.NewSource(7))
+    epsilon := 1e-9
+
+    for _, table := range tables {
+        value := randomContraction(3, table.norm)
+        direction := mat.NewDense(3, 3, nil)
+        direction.Apply(func(i, j int, v float64) float64 { return rnd.NormFloat64() }, direction)
+        direction.Scale(epsilon * mat.Norm(value, 2) / mat.Norm(direction, 2), direction)
+        perturbed := mat.NewDense(3, 3, nil)
+        perturbed.Add(value, direction)
+
+        unitary, condition, err := UnitaryNDilationWithCondition(value, 2, WithBackend(SingularValueDecomposition))
+
+        if err != nil {
+            t.Fatalf("Unexpected error for %s: %v", table.desc, err)
+        }
+
+        unitaryPerturbed, err := UnitaryNDilation(perturbed, 2, WithBackend(SingularValueDecomposition))
+
+        if err != nil {
+            t.Fatalf("Unexpected error for perturbed %s: %v", table.desc, err)
+        }
+
+        diff := mat.NewDense(9, 9, nil)
+        diff.Sub(unitary, unitaryPerturbed)
+        change := mat.Norm(diff, 2) / mat.Norm(unitary, 2)
+
+        if change > 1.01 * condition.Perturbation(epsilon) {
+            t.Errorf("Change %e of %s exceeds the estimate %e", change, table.desc, condition.Perturbation(epsilon))
+        }
+
+        if math.Abs(condition.Norm - operatorNorm(value)) > 1e-12 {
+            t.Errorf("Wrong norm of %s: %v", table.desc, condition.Norm)
+        }
+    }
+}
+
+func TestConditionGrowsWithNorm(t *testing.T) {
+    previous := 0.0
+
+    for _, norm := range []float64{0.5, 0.9, 0.99, 0.999999} {
+        condition, err := ConditionOf(randomContraction(3, norm), 2)
+
+        if err != nil || condition.Relative <= previous {
+            t.Errorf("Condition does not grow with the norm %v, got: %+v, %v", norm, condition, err)
+        }
+
+        previous = condition.Relative
+    }
+}
+
+func TestConditionOfErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+        expected error
+    }{
+        {desc: "nil", value: nil, degree: 2, expected: ErrNilMatrix},
+        {desc: "degree", value: mat.NewDense(1, 1, []float64{0.5}), degree: 0, expected: &DegreeError{Degree: 0}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            if _, err := ConditionOf(table.value, table.degree); !reflect.DeepEqual(err, table.expected) {
+                t.Errorf("Wrong error, got: %v, want: %v", err, table.expected)
+            }
+        })
+    }
+
+    condition, err := ConditionOf(mat.NewDense(1, 1, []float64{2}), 2)
+
+    if err != nil || !math.IsInf(condition.Absolute, 1) || !math.IsInf(condition.Relative, 1) {
+        t.Errorf("Wrong condition of a matrix that is not a contraction, got: %+v, %v", condition, err)
+    }
+}
+
+func TestConditionOfRectangular(t *testing.T) {
+    value := mat.NewDense(1, 3, []float64{0.6, 0, 0})
+    unitary, condition, err := UnitaryNDilationWithCondition(value, 1, WithBackend(SingularValueDecomposition))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    // U has dimension 1 + 3, so ‖U‖_F = 2
+    if norm := mat.Norm(unitary, 2); math.Abs(norm - 2) > 1e-12 {
+        t.Fatalf("Wrong norm of the dilation, got: %v, want: 2", norm)
+    }
+
+    absolute := math.Sqrt(2 * (1 + 0.36 / 0.64))
+    expected := Condition{Norm: 0.6, DefectGap: 0.64, Absolute: absolute, Relative: absolute * 0.6 / 2}
+
+    if math.Abs(condition.Norm - expected.Norm) > 1e-15 || math.Abs(condition.DefectGap - expected.DefectGap) > 1e-15 ||
+        math.Abs(condition.Absolute - expected.Absolute) > 1e-14 || math.Abs(condition.Relative - expected.Relative) > 1e-14 {
+        t.Errorf("Wrong condition, got: %+v, want: %+v", condition, expected)
+    }
+
+    if c, err := ConditionOf(value, 1); err != nil || c != condition {
+        t.Errorf("ConditionOf differs, got: %+v, %v, want: %+v", c, err, condition)
+    }
+}
@@ -0,0 +1,89 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+// The decomposition of a contraction into a direct sum by UnitaryNDilationDecomposed
+type Decomposition struct {
+    // Components[k] lists the indices of t in the k-th block of the direct sum in ascending order
+    Components [][]int
+    // Blocks[k] is the unitary n-dilation of the principal submatrix of t for Components[k]
+    Blocks []*mat.Dense
+    // the dilation U of t is Pᵀ B P for the permutation matrix P and the block diagonal matrix B of the Blocks,
+    // i.e. U_ij = B_{p[i], p[j]} for p = Permutation.Permutation()
+    Permutation *Permutation
+}
+
+// returns the block diagonal matrix of the Blocks, which is the dilation permuted by Permutation
+func (d *Decomposition) BlockDiagonal() *mat.Dense {
+    size, _ := d.Permutation.Dims()
+    diagonal := mat.NewDense(size, size, nil)
+    offset := 0
+
+    for _, b := range d.Blocks {
+        s, _ := b.Dims()
+        diagonal.Slice(offset, offset + s, offset, offset + s).(*mat.Dense).Copy(b)
+        offset += s
+    }
+
+    return diagonal
+}
+
+/*
+    dilates the blocks of UnitaryNDilationDecomposed concurrently, one goroutine per block, instead of one after another.
+    An Observer then receives the events of several blocks at the same time from different goroutines and must be safe for concurrent use.
+    The other entry points ignore this option.
+*/
+func WithParallelBlocks() Option {
+    return func(o *options) {
+        o.parallel = true
+    }
+}
+
+// returns how the blocks of UnitaryNDilationDecomposed are scheduled, see WithParallelBlocks
+func (o *options) forEachBlock() func(int, func(int) error) error {
+    if o.parallel {
+        return dilation.Parallel
+    }
+
+    return dilation.Sequential
+}
+
+/*
+    Same as UnitaryNDilation, but dilates each block of a square t that is a direct sum of smaller blocks, possibly after a permutation, separately.
+    The blocks are the connected components of the combined sparsity patterns of t, TᵀT and TTᵀ, i.e. of their entries that are not exactly zero,
+    and only square roots of the dimension of the blocks are calculated. Only symmetric permutations of t are detected, the same permutation
+    of its rows and columns, as only those keep the compressions of the dilation the powers of t. The result has the same layout as UnitaryNDilation,
+    the Decomposition contains the blocks, their dilations and the permutation onto the direct sum of these dilations.
+    The blocks are dilated one after another, so an Observer receives the events of each block on the calculating goroutine,
+    unless WithParallelBlocks is passed.
+    Unlike UnitaryNDilation, t must be square, a rectangular t results in the error "Matrix does not have square dimension".
+*/
+func UnitaryNDilationDecomposed(t mat.Matrix, n int, opts ...Option) (unitary *mat.Dense, decomposition *Decomposition, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return nil, nil, err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return nil, nil, err
+    }
+
+    dilate := func(block mat.Matrix) (*mat.Dense, error) {
+        return o.dilate(block, n)
+    }
+
+    unitary, components, blocks, permutation, err := dilation.UnitaryNDilationDecomposed(dilate, o.forEachBlock(), t, n)
+
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return unitary, &Decomposition{Components: components, Blocks: blocks, Permutation: permutation}, nil
+}
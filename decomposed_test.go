@@ -0,0 +1,144 @@
+package godilation
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func TestUnitaryNDilationDecomposed(t *testing.T) {
+    // the direct sum of [[0.5, 0.5], [0, 0.5]] on {0, 3}, [[0.1, 0.3], [0.2, 0.4]] on {1, 4} and 0.7 on {2}
+    value := mat.NewDense(5, 5, []float64{
+        0.5,0,0,0.5,0,
+        0,0.1,0,0,0.3,
+        0,0,0.7,0,0,
+        0,0,0,0.5,0,
+        0,0.2,0,0,0.4,
+    })
+    degree := 2
+
+    unitary, decomposition, err := UnitaryNDilationDecomposed(value, degree, WithBackend(SingularValueDecomposition))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expectedComponents := [][]int{{0, 3}, {1, 4}, {2}}
+
+    if !reflect.DeepEqual(decomposition.Components, expectedComponents) {
+        t.Errorf("Wrong components, got: %v, want: %v", decomposition.Components, expectedComponents)
+    }
+
+    if len(decomposition.Blocks) != 3 {
+        t.Fatalf("Wrong number of blocks, got: %d, want: 3", len(decomposition.Blocks))
+    }
+
+    if r, _ := decomposition.Blocks[2].Dims(); r != degree + 1 {
+        t.Errorf("Wrong dimension of the dilation of the scalar block, got: %d, want: %d", r, degree + 1)
+    }
+
+    expected, err := UnitaryNDilation(value, degree, WithBackend(SingularValueDecomposition))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !mat.EqualApprox(unitary, expected, 1e-14) {
+        t.Errorf("Decomposed dilation differs from dilation, got: %v, want: %v", mat.Formatted(unitary), mat.Formatted(expected))
+    }
+
+    isNDilation(t, unitary, value, degree, 1e-12)
+
+    diagonal := decomposition.BlockDiagonal()
+    perm := decomposition.Permutation.Permutation()
+
+    var permuted mat.Dense
+    permuted.Product(decomposition.Permutation.T(), diagonal, decomposition.Permutation)
+
+    if !mat.Equal(&permuted, unitary) {
+        t.Errorf("Dilation is not Pᵀ B P, got: %v, want: %v", mat.Formatted(&permuted), mat.Formatted(unitary))
+    }
+
+    for i := range perm {
+        for j := range perm {
+            if unitary.At(i, j) != diagonal.At(perm[i], perm[j]) {
+                t.Fatalf("Permutation does not map onto the block diagonal matrix at (%d, %d)", i, j)
+            }
+        }
+    }
+}
+
+func TestUnitaryNDilationDecomposedExponentialMethod(t *testing.T) {
+    value := mat.NewDense(3, 3, []float64{0.5,0,0.5, 0,0.3,0, 0,0,0.5,})
+    unitary, decomposition, err := UnitaryNDilationDecomposed(value, 3)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !reflect.DeepEqual(decomposition.Components, [][]int{{0, 2}, {1}}) {
+        t.Errorf("Wrong components, got: %v", decomposition.Components)
+    }
+
+    isNDilation(t, unitary, value, 3, 1e-5)
+}
+
+func TestUnitaryNDilationDecomposedParallel(t *testing.T) {
+    value := mat.NewDense(5, 5, []float64{
+        0.5,0,0,0.5,0,
+        0,0.1,0,0,0.3,
+        0,0,0.7,0,0,
+        0,0,0,0.5,0,
+        0,0.2,0,0,0.4,
+    })
+
+    expected, expectedDecomposition, err := UnitaryNDilationDecomposed(value, 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    unitary, decomposition, err := UnitaryNDilationDecomposed(value, 2, WithParallelBlocks())
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !mat.Equal(unitary, expected) || !reflect.DeepEqual(decomposition, expectedDecomposition) {
+        t.Errorf("Parallel dilation differs from sequential dilation, got: %v, want: %v", mat.Formatted(unitary), mat.Formatted(expected))
+    }
+
+    // the first block is a contraction, the second is not
+    _, _, err = UnitaryNDilationDecomposed(mat.NewDiagDense(2, []float64{0.5, 2,}), 1, WithParallelBlocks())
+
+    if !reflect.DeepEqual(err, fmt.Errorf("Input is not a contraction")) {
+        t.Errorf("Unexpected error, got: %v", err)
+    }
+}
+
+func TestUnitaryNDilationDecomposedErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+        expected error
+    }{
+        {desc: "nil matrix", value: nil, degree: 1, expected: ErrNilMatrix},
+        {desc: "invalid degree", value: mat.NewDense(1, 1, nil), degree: 0, expected: &DegreeError{Degree: 0}},
+        {desc: "rectangular matrix", value: mat.NewDense(1, 2, nil), degree: 1, expected: fmt.Errorf("Matrix does not have square dimension")},
+        {desc: "block that is not a contraction", value: mat.NewDiagDense(2, []float64{0.5, 2,}), degree: 1, expected: fmt.Errorf("Input is not a contraction")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitary, decomposition, err := UnitaryNDilationDecomposed(table.value, table.degree)
+
+            if unitary != nil || decomposition != nil || !reflect.DeepEqual(err, table.expected) {
+                t.Errorf("Unexpected result, got: %v, %v, %v, want: nil, nil, %v", unitary, decomposition, err, table.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,121 @@
+/*
+    Package definiteness decides whether a matrix is positive definite and classifies symmetric matrices by the signs of their eigenvalues.
+    The eigendecomposition is injected as an EigenComputer, so its failures can be simulated in tests.
+*/
+package definiteness
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+// Definiteness of a symmetric matrix
+type Definiteness int
+
+const (
+    // all eigenvalues are positive
+    PositiveDefinite Definiteness = iota
+    // all eigenvalues are non-negative and at least one is zero, this includes the zero matrix
+    PositiveSemidefinite
+    // all eigenvalues are negative
+    NegativeDefinite
+    // all eigenvalues are non-positive, at least one is zero and at least one is negative
+    NegativeSemidefinite
+    // there are positive and negative eigenvalues
+    Indefinite
+)
+
+func (d Definiteness) String() string {
+    switch d {
+    case PositiveDefinite:
+        return "positive definite"
+    case PositiveSemidefinite:
+        return "positive semidefinite"
+    case NegativeDefinite:
+        return "negative definite"
+    case NegativeSemidefinite:
+        return "negative semidefinite"
+    case Indefinite:
+        return "indefinite"
+    }
+    return fmt.Sprintf("Definiteness(%d)", int(d))
+}
+
+// Number of positive, zero and negative eigenvalues of a symmetric matrix
+type Inertia struct {
+    Positive int
+    Zero int
+    Negative int
+}
+
+// Result of Classify
+type Classification struct {
+    Definiteness Definiteness
+    Inertia Inertia
+    // smallest and largest eigenvalue
+    MinEigenvalue float64
+    MaxEigenvalue float64
+}
+
+func definitenessOf(inertia Inertia) Definiteness {
+    switch {
+    case inertia.Positive > 0 && inertia.Negative > 0:
+        return Indefinite
+    case inertia.Negative > 0 && inertia.Zero > 0:
+        return NegativeSemidefinite
+    case inertia.Negative > 0:
+        return NegativeDefinite
+    case inertia.Zero > 0:
+        return PositiveSemidefinite
+    }
+    return PositiveDefinite
+}
+
+/*
+    Classifies the symmetric matrix a by the eigenvalues eigen calculates for it, pass &mat.Eigen{} for gonum's eigendecomposition.
+    Eigenvalues with an absolute value of at most tolerance are counted as zero.
+    Returns an error, if the factorization was not successful or an eigenvalue has an imaginary part larger than tolerance,
+    which can only happen due to rounding errors or an EigenComputer that does not treat a as symmetric.
+*/
+func Classify(eigen EigenComputer, a mat.Symmetric, tolerance float64) (c Classification, err error) {
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(a); err != nil {
+        return Classification{}, err
+    }
+
+    // gonum's eigendecomposition does not terminate for non-finite input
+    if err := guard.CheckFinite(guard.StageInput, a); err != nil {
+        return Classification{}, err
+    }
+
+    if ok := eigen.Factorize(a, mat.EigenNone); !ok {
+        return Classification{}, fmt.Errorf("eigen: Factorize unsuccessful %v", mat.Formatted(a, mat.Prefix("    "), mat.Squeeze()))
+    }
+
+    c = Classification{MinEigenvalue: math.Inf(1), MaxEigenvalue: math.Inf(-1)}
+
+    for _, value := range eigen.Values(nil) {
+        if math.Abs(imag(value)) > tolerance {
+            return Classification{}, fmt.Errorf("Eigenvalue is not real: %v", value)
+        }
+
+        r := real(value)
+        c.MinEigenvalue = math.Min(c.MinEigenvalue, r)
+        c.MaxEigenvalue = math.Max(c.MaxEigenvalue, r)
+
+        switch {
+        case r > tolerance:
+            c.Inertia.Positive++
+        case r < -tolerance:
+            c.Inertia.Negative++
+        default:
+            c.Inertia.Zero++
+        }
+    }
+
+    c.Definiteness = definitenessOf(c.Inertia)
+    return c, nil
+}
@@ -0,0 +1,153 @@
+package definiteness
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "reflect"
+    "testing"
+)
+
+var dummySymmetric = mat.NewSymDense(3, nil)
+
+func TestClassify(t *testing.T) {
+    tables := []struct {
+        desc string
+        values []complex128
+        tolerance float64
+        expected Classification
+    }{
+        {
+            desc: "positive definite",
+            values: []complex128{1, 2, 3},
+            expected: Classification{Definiteness: PositiveDefinite, Inertia: Inertia{Positive: 3}, MinEigenvalue: 1, MaxEigenvalue: 3},
+        },
+        {
+            desc: "positive semidefinite",
+            values: []complex128{0, 2, 3},
+            expected: Classification{Definiteness: PositiveSemidefinite, Inertia: Inertia{Positive: 2, Zero: 1}, MinEigenvalue: 0, MaxEigenvalue: 3},
+        },
+        {
+            desc: "negative definite",
+            values: []complex128{-1, -2, -3},
+            expected: Classification{Definiteness: NegativeDefinite, Inertia: Inertia{Negative: 3}, MinEigenvalue: -3, MaxEigenvalue: -1},
+        },
+        {
+            desc: "negative semidefinite",
+            values: []complex128{-1, 0, -3},
+            expected: Classification{Definiteness: NegativeSemidefinite, Inertia: Inertia{Zero: 1, Negative: 2}, MinEigenvalue: -3, MaxEigenvalue: 0},
+        },
+        {
+            desc: "indefinite",
+            values: []complex128{-1, 0, 3},
+            expected: Classification{Definiteness: Indefinite, Inertia: Inertia{Positive: 1, Zero: 1, Negative: 1}, MinEigenvalue: -1, MaxEigenvalue: 3},
+        },
+        {
+            desc: "zero matrix",
+            values: []complex128{0, 0, 0},
+            expected: Classification{Definiteness: PositiveSemidefinite, Inertia: Inertia{Zero: 3}, MinEigenvalue: 0, MaxEigenvalue: 0},
+        },
+        {
+            desc: "counts eigenvalues within the tolerance as zero",
+            values: []complex128{-1e-12, 1e-12, 1},
+            tolerance: 1e-10,
+            expected: Classification{Definiteness: PositiveSemidefinite, Inertia: Inertia{Positive: 1, Zero: 2}, MinEigenvalue: -1e-12, MaxEigenvalue: 1},
+        },
+        {
+            desc: "accepts imaginary parts within the tolerance",
+            values: []complex128{1 + 1e-12i, 2},
+            tolerance: 1e-10,
+            expected: Classification{Definiteness: PositiveDefinite, Inertia: Inertia{Positive: 2}, MinEigenvalue: 1, MaxEigenvalue: 2},
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            c, err := Classify(createEigenMock(true, table.values), dummySymmetric, table.tolerance)
+
+            if err != nil {
+                t.Errorf("Classify returned unexpected error: %v", err)
+            }
+
+            if !reflect.DeepEqual(c, table.expected) {
+                t.Errorf("Classify was incorrect, got: %+v, want: %+v.", c, table.expected)
+            }
+        })
+    }
+}
+
+func TestClassifyFactorizeNotOk(t *testing.T) {
+    c, err := Classify(createEigenMock(false, []complex128{}), dummySymmetric, 0)
+    expectedError := fmt.Errorf("eigen: Factorize unsuccessful %v", mat.Formatted(dummySymmetric, mat.Prefix("    "), mat.Squeeze()))
+
+    if !reflect.DeepEqual(err, expectedError) {
+        t.Errorf("Wrong error returned, got: %v, want: %v.", err, expectedError)
+    }
+
+    if c != (Classification{}) {
+        t.Errorf("Classify returned unexpected classification: %+v", c)
+    }
+}
+
+func TestClassifyNotReal(t *testing.T) {
+    _, err := Classify(createEigenMock(true, []complex128{1, 1 + 1i}), dummySymmetric, 1e-10)
+    expectedError := fmt.Errorf("Eigenvalue is not real: %v", 1 + 1i)
+
+    if !reflect.DeepEqual(err, expectedError) {
+        t.Errorf("Wrong error returned, got: %v, want: %v.", err, expectedError)
+    }
+}
+
+func TestClassifyWithEigen(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Symmetric
+        expected Definiteness
+        inertia Inertia
+    }{
+        {desc: "eye matrix", value: mat.NewDiagDense(3, []float64{1, 1, 1}), expected: PositiveDefinite, inertia: Inertia{Positive: 3}},
+        {desc: "singular matrix", value: mat.NewSymDense(2, []float64{1, 1, 1, 1}), expected: PositiveSemidefinite, inertia: Inertia{Positive: 1, Zero: 1}},
+        {desc: "indefinite matrix", value: mat.NewSymDense(2, []float64{0, 1, 1, 0}), expected: Indefinite, inertia: Inertia{Positive: 1, Negative: 1}},
+        {desc: "negative definite matrix", value: mat.NewSymDense(3, []float64{-2, 1, 0, 1, -2, 1, 0, 1, -2}), expected: NegativeDefinite, inertia: Inertia{Negative: 3}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            c, err := Classify(&mat.Eigen{}, table.value, 1e-12)
+
+            if err != nil {
+                t.Errorf("Classify returned unexpected error: %v", err)
+            }
+
+            if c.Definiteness != table.expected || c.Inertia != table.inertia {
+                t.Errorf("Classify was incorrect, got: %v %+v, want: %v %+v.", c.Definiteness, c.Inertia, table.expected, table.inertia)
+            }
+        })
+    }
+}
+
+func TestClassifyInvalidInput(t *testing.T) {
+    c, err := Classify(&mat.Eigen{}, &mat.SymDense{}, 0)
+
+    if err != ErrEmptyMatrix || !reflect.DeepEqual(c, Classification{}) {
+        t.Errorf("Classify returned wrong value, got: %v, %v, want: %v, %v", c, err, Classification{}, ErrEmptyMatrix)
+    }
+}
+
+func TestClassifyNonFinite(t *testing.T) {
+    c, err := Classify(&mat.Eigen{}, mat.NewSymDense(2, []float64{1, math.NaN(), math.NaN(), 1}), 0)
+
+    if _, ok := err.(*NonFiniteError); !ok || !reflect.DeepEqual(c, Classification{}) {
+        t.Errorf("Classify returned wrong value, got: %v, %v", c, err)
+    }
+
+    isPd, err := IsPositiveDefinite(&mat.Eigen{}, mat.NewDense(1, 1, []float64{math.Inf(1)}))
+
+    if _, ok := err.(*NonFiniteError); isPd || !ok {
+        t.Errorf("IsPositiveDefinite returned wrong value, got: %t, %v", isPd, err)
+    }
+}
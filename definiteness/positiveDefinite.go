@@ -0,0 +1,77 @@
+package definiteness
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math/cmplx"
+)
+
+type EigenComputer interface {
+    Factorize(mat.Matrix, mat.EigenKind) bool
+    Values([]complex128) []complex128
+}
+
+func isSymmetric(a mat.Matrix) bool {
+    return mat.Equal(a, a.T())
+}
+
+var (
+    // returned for a nil matrix
+    ErrNilMatrix = guard.ErrNilMatrix
+    // returned for a matrix without rows or columns
+    ErrEmptyMatrix = guard.ErrEmptyMatrix
+)
+
+// returned instead of a panic, e.g. of gonum or of the EigenComputer
+type PanicError = guard.PanicError
+
+// returned for a matrix with a NaN or ±Inf entry, its Stage is always StageInput
+type NonFiniteError = guard.NonFiniteError
+
+func IsPositiveDefinite(eigen EigenComputer, candidate mat.Matrix) (isPositiveDefinite bool, err error) {
+    // runs after guard.Recover, so a recovered panic does not report a positive definite matrix
+    defer func() {
+        if err != nil {
+            isPositiveDefinite = false
+        }
+    }()
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(candidate); err != nil {
+        return false, err
+    }
+
+    // gonum's eigendecomposition does not terminate for non-finite input
+    if err := guard.CheckFinite(guard.StageInput, candidate); err != nil {
+        return false, err
+    }
+
+    m, n := candidate.Dims()
+    c := mat.NewDense(m, n, nil)
+    c.CloneFrom(candidate)
+    err = nil
+    isPositiveDefinite = true
+
+    if !isSymmetric(candidate) {
+        isPositiveDefinite = false
+        return
+    }
+
+    ok := eigen.Factorize(c, mat.EigenNone)
+
+    if ok {
+        for _, val := range eigen.Values(nil) {
+            r, theta := cmplx.Polar(val)
+            if theta != 0 || r == 0 {
+                isPositiveDefinite = false
+
+                return
+            }
+        }
+
+        return
+    }
+
+    return false, fmt.Errorf("eigen: Factorize unsuccessful %v", mat.Formatted(candidate, mat.Prefix("    "), mat.Squeeze()))
+}
@@ -1,4 +1,4 @@
-package positiveDefinite
+package definiteness
 
 import (
     "fmt"
@@ -30,7 +30,7 @@ var dummyMatrix = mat.NewDense(2, 2, nil)
 func TestPdForMatrix(t *testing.T) {
     tables := []struct {
         desc string
-        candidate *mat.Dense
+        candidate mat.Matrix
         values []complex128
         isPd bool
         factorizeOk bool
@@ -40,6 +40,8 @@ func TestPdForMatrix(t *testing.T) {
         {values: []complex128{0+0i,5+7i}, isPd: false, factorizeOk: true, candidate: dummyMatrix, desc: "checks for factorize error"},
         {values: []complex128{}, isPd: false, factorizeOk: true, candidate: mat.NewDense(2, 2, []float64{0,1,0,0}), desc: "checks is symmetric"},
         {values: []complex128{}, isPd: false, factorizeOk: true, candidate: mat.NewDense(2, 3, nil), desc: "checks is square matrix"},
+        {values: []complex128{1+0i,5+0i}, isPd: true, factorizeOk: true, candidate: mat.NewSymDense(2, nil), desc: "accepts a symmetric matrix"},
+        {values: []complex128{}, isPd: false, factorizeOk: true, candidate: mat.NewTriDense(2, mat.Upper, []float64{1,1,0,1}), desc: "accepts a triangular matrix"},
     }
 
     for _, table := range tables {
@@ -68,3 +70,17 @@ func TestPdFactorizeNotOk(t *testing.T) {
         t.Errorf("IsPositiveDefinite was incorrect, got: %t, want: %t.", isPd, false)
     }
 }
+
+func TestPdInvalidInput(t *testing.T) {
+    isPd, err := IsPositiveDefinite(createEigenMock(true, []complex128{}), nil)
+
+    if isPd || err != ErrNilMatrix {
+        t.Errorf("IsPositiveDefinite returned wrong value, got: %t, %v, want: false, %v", isPd, err, ErrNilMatrix)
+    }
+
+    isPd, err = IsPositiveDefinite(nil, dummyMatrix)
+
+    if _, ok := err.(*PanicError); isPd || !ok {
+        t.Errorf("IsPositiveDefinite returned wrong value for nil EigenComputer, got: %t, %v", isPd, err)
+    }
+}
@@ -0,0 +1,113 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/sqrtm"
+    "gonum.org/v1/gonum/mat"
+)
+
+// validates a direction or gradient that accompanies the contraction t
+func validateDirection(t, e mat.Matrix, n int) error {
+    if err := validate(t, n); err != nil {
+        return err
+    }
+
+    if err := guard.CheckMatrix(e); err != nil {
+        return err
+    }
+
+    return guard.CheckFinite(guard.StageInput, e)
+}
+
+// reports whether WithSpecialOrthogonal negates the last column of the dilation of t, which negates the last column of its derivative as well
+func (o *options) reflected(t mat.Matrix, n int) (bool, error) {
+    if !o.special {
+        return false, nil
+    }
+
+    unitary, err := dilation.UnitaryNDilation(o.defectsWith(o.symmetricSquareRoot), o.newBlockMatrix, t, n)
+
+    if err != nil {
+        return false, err
+    }
+
+    return dilation.ReversesOrientation(unitary), nil
+}
+
+/*
+    Returns the directional derivative dU[T; E] of UnitaryNDilation(t, n) in direction e, which has the dimension of t.
+    Unlike UnitaryNDilation, t must be square, a rectangular t results in the error "Matrix does not have square dimension".
+    The derivatives of the defect operators solve the Sylvester equations of the derivative of the square root, see sqrtm.Frechet.
+    The derivative exists for the positive definite defect operators only, so with the ExponentialMethod backend their square roots are calculated
+    by an eigendecomposition instead, as the Exponential Method may converge to another square root.
+    With WithSpecialOrthogonal the derivative of the special orthogonal dilation is returned: the sign of the determinant does not change
+    for small changes of t, so its last column is negated if the last column of the dilation is.
+    WithNearestContraction results in an *OptionError, as the derivative of the projection is not calculated.
+*/
+func UnitaryNDilationDerivative(t, e mat.Matrix, n int, opts ...Option) (derivative *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validateDirection(t, e, n); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if err := o.rejectProjection("the derivative of the projection is not calculated"); err != nil {
+        return nil, err
+    }
+
+    derivative, err = dilation.UnitaryNDilationDerivative(o.defectsWith(o.symmetricSquareRoot), sqrtm.Frechet, o.newBlockMatrix, t, e, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    reflected, err := o.reflected(t, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    if reflected {
+        dilation.NegateLastColumn(derivative)
+    }
+
+    return derivative, nil
+}
+
+/*
+    Returns the adjoint of UnitaryNDilationDerivative applied to g, which has the dimension of the dilation:
+    for a loss L(U(T)) with gradient g = ∂L/∂U, the result is the gradient ∂L/∂T for back-propagation,
+    so that ⟨g, UnitaryNDilationDerivative(t, e, n)⟩ = ⟨UnitaryNDilationAdjoint(t, g, n), e⟩ for every direction e. T must be square as well.
+    With WithSpecialOrthogonal the last column of g is negated if the last column of the dilation is, which is the adjoint of the same reflection
+    of the derivative. WithNearestContraction results in an *OptionError as well.
+*/
+func UnitaryNDilationAdjoint(t, g mat.Matrix, n int, opts ...Option) (gradient *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validateDirection(t, g, n); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if err := o.rejectProjection("the derivative of the projection is not calculated"); err != nil {
+        return nil, err
+    }
+
+    reflected, err := o.reflected(t, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    if reflected {
+        negated := mat.DenseCopyOf(g)
+        dilation.NegateLastColumn(negated)
+        g = negated
+    }
+
+    return dilation.UnitaryNDilationAdjoint(o.defectsWith(o.symmetricSquareRoot), sqrtm.Frechet, t, g, n)
+}
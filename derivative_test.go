@@ -0,0 +1,185 @@
+package godilation
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func randomMatrix(rnd *rand.Rand, r, c int) *mat.Dense {
+    d := mat.NewDense(r, c, nil)
+    d.Apply(func(i, j int, v float64) float64 { return rnd.NormFloat64() }, d)
+    return d
+}
+
+func TestUnitaryNDilationDerivativeFiniteDifferences(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        degree int
+    }{
+        {desc: "matrix of examples/basic.go", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 2},
+        {desc: "random contraction", value: randomContraction(3, 0.8), degree: 3},
+        {desc: "degree 1", value: randomContraction(3, 0.6), degree: 1},
+    }
+
+    for i, table := range tables {
+        table, rnd := table, rand.New(rand.NewSource(int64(i)))
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            m, _ := table.value.Dims()
+            e := randomMatrix(rnd, m, m)
+            // the reference uses the singular value decomposition, which always calculates the positive definite defect operators
+            opts := []Option{WithBackend(SingularValueDecomposition)}
+
+            derivative, err := UnitaryNDilationDerivative(table.value, e, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            h := 1e-6
+            plus, minus := mat.NewDense(m, m, nil), mat.NewDense(m, m, nil)
+            plus.Scale(h, e)
+            minus.Sub(table.value, plus)
+            plus.Add(table.value, plus)
+
+            unitaryPlus, err := UnitaryNDilation(plus, table.degree, opts...)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            unitaryMinus, err := UnitaryNDilation(minus, table.degree, opts...)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            central := mat.NewDense(m * (table.degree + 1), m * (table.degree + 1), nil)
+            central.Sub(unitaryPlus, unitaryMinus)
+            central.Scale(1 / (2 * h), central)
+
+            if !mat.EqualApprox(derivative, central, 1e-7) {
+                t.Errorf("Derivative does not match central differences, got: %v, want: %v", mat.Formatted(derivative), mat.Formatted(central))
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationAdjoint(t *testing.T) {
+    rnd := rand.New(rand.NewSource(17))
+    value := randomContraction(3, 0.7)
+    degree := 2
+
+    for _, backend := range []Backend{ExponentialMethod, SingularValueDecomposition} {
+        e := randomMatrix(rnd, 3, 3)
+        g := randomMatrix(rnd, 9, 9)
+
+        derivative, err := UnitaryNDilationDerivative(value, e, degree, WithBackend(backend))
+
+        if err != nil {
+            t.Fatalf("Unexpected error: %v", err)
+        }
+
+        gradient, err := UnitaryNDilationAdjoint(value, g, degree, WithBackend(backend))
+
+        if err != nil {
+            t.Fatalf("Unexpected error: %v", err)
+        }
+
+        var lhs, rhs mat.Dense
+        lhs.MulElem(g, derivative)
+        rhs.MulElem(gradient, e)
+
+        if l, r := mat.Sum(&lhs), mat.Sum(&rhs); math.Abs(l - r) > 1e-9 * math.Abs(l) {
+            t.Errorf("Adjoint does not match the derivative for backend %d: %v != %v", backend, l, r)
+        }
+    }
+}
+
+func TestUnitaryNDilationAdjointGradient(t *testing.T) {
+    // the gradient of L(U) = ⟨G, U⟩ is compared with central differences of L in each entry of T
+    rnd := rand.New(rand.NewSource(19))
+    value := mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,})
+    g := randomMatrix(rnd, 6, 6)
+    opts := []Option{WithBackend(SingularValueDecomposition)}
+
+    gradient, err := UnitaryNDilationAdjoint(value, g, 2, opts...)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    loss := func(t *testing.T, v *mat.Dense) float64 {
+        unitary, err := UnitaryNDilation(v, 2, opts...)
+
+        if err != nil {
+            t.Fatalf("Unexpected error: %v", err)
+        }
+
+        var p mat.Dense
+        p.MulElem(g, unitary)
+        return mat.Sum(&p)
+    }
+
+    h := 1e-6
+
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            plus, minus := mat.DenseCopyOf(value), mat.DenseCopyOf(value)
+            plus.Set(i, j, value.At(i, j) + h)
+            minus.Set(i, j, value.At(i, j) - h)
+
+            if central := (loss(t, plus) - loss(t, minus)) / (2 * h); math.Abs(central - gradient.At(i, j)) > 1e-7 {
+                t.Errorf("Wrong gradient at (%d, %d), got: %v, want: %v", i, j, gradient.At(i, j), central)
+            }
+        }
+    }
+}
+
+func TestUnitaryNDilationDerivativeErrors(t *testing.T) {
+    value := mat.NewDense(2, 2, []float64{0.5,0,0,0.5,})
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        direction mat.Matrix
+        gradient mat.Matrix
+        expected error
+    }{
+        {desc: "nil direction", value: value, direction: nil, gradient: nil, expected: ErrNilMatrix},
+        {desc: "non-finite direction", value: value, direction: mat.NewDense(2, 2, []float64{math.NaN(),0,0,0}), gradient: mat.NewDense(6, 6, []float64{35: math.NaN()}),
+            expected: &NonFiniteError{Stage: StageInput, Row: 0, Col: 0, Value: math.NaN()}},
+        {desc: "not a contraction", value: mat.NewDense(2, 2, []float64{2,0,0,0.5,}), direction: value, gradient: mat.NewDense(6, 6, nil), expected: fmt.Errorf("Input is not a contraction")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            if derivative, err := UnitaryNDilationDerivative(table.value, table.direction, 2); derivative != nil || fmt.Sprint(err) != fmt.Sprint(table.expected) {
+                t.Errorf("Wrong derivative result, got: %v, %v, want: nil, %v", derivative, err, table.expected)
+            }
+
+            if gradient, err := UnitaryNDilationAdjoint(table.value, table.gradient, 2); gradient != nil || err == nil {
+                t.Errorf("Wrong adjoint result, got: %v, %v", gradient, err)
+            }
+        })
+    }
+
+    expected := fmt.Errorf("Unexpected dimension of direction: (3, 3) (Expecting (2, 2))")
+
+    if _, err := UnitaryNDilationDerivative(value, mat.NewDense(3, 3, nil), 2); !reflect.DeepEqual(err, expected) {
+        t.Errorf("Wrong error, got: %v, want: %v", err, expected)
+    }
+
+    expected = fmt.Errorf("Unexpected dimension of gradient: (2, 2) (Expecting (6, 6))")
+
+    if _, err := UnitaryNDilationAdjoint(value, value, 2); !reflect.DeepEqual(err, expected) {
+        t.Errorf("Wrong error, got: %v, want: %v", err, expected)
+    }
+}
@@ -0,0 +1,83 @@
+//go:build go1.18
+// +build go1.18
+
+package godilation
+
+import (
+    "encoding/binary"
+    "math"
+    "gonum.org/v1/gonum/mat"
+    "testing"
+)
+
+// returns a matrix with the entries decoded from data, 2 bytes per entry in [-2, 2), missing entries are 0.
+// The smallest and largest encoded values decode to NaN and +Inf, so non-finite input is covered as well.
+// The dimension is limited to 8, a negative dimension results in a nil matrix and 0 in an empty one
+func fuzzMatrix(r, c int8, data []byte) mat.Matrix {
+    if r < 0 || c < 0 {
+        return nil
+    }
+
+    if r == 0 || c == 0 {
+        return &mat.Dense{}
+    }
+
+    m := mat.NewDense(int(r - 1) % 8 + 1, int(c - 1) % 8 + 1, nil)
+    rows, cols := m.Dims()
+
+    for i := 0; i < rows * cols && 2 * (i + 1) <= len(data); i++ {
+        switch v := int16(binary.LittleEndian.Uint16(data[2 * i:])); v {
+        case math.MinInt16:
+            m.Set(i / cols, i % cols, math.NaN())
+        case math.MaxInt16:
+            m.Set(i / cols, i % cols, math.Inf(1))
+        default:
+            m.Set(i / cols, i % cols, float64(v) / (1 << 14))
+        }
+    }
+
+    return m
+}
+
+func fuzzData(values ...float64) []byte {
+    data := make([]byte, 2 * len(values))
+
+    for i, value := range values {
+        binary.LittleEndian.PutUint16(data[2 * i:], uint16(int16(value * (1 << 14))))
+    }
+
+    return data
+}
+
+// Checks that UnitaryNDilation returns either a finite dilation or an error and never panics. The degree is limited to [-8, 8]
+func FuzzUnitaryNDilation(f *testing.F) {
+    f.Add(int8(2), int8(2), int8(2), false, fuzzData(0.5, 0.5, 0, 0.5))
+    f.Add(int8(2), int8(2), int8(1), true, fuzzData(0.5, 0.9, 0, 0.5))
+    f.Add(int8(1), int8(2), int8(3), false, fuzzData(0.1, 0.2))
+    f.Add(int8(0), int8(0), int8(2), true, []byte{})
+    f.Add(int8(-1), int8(1), int8(-1), false, []byte{})
+    f.Add(int8(3), int8(3), int8(2), false, fuzzData(1, 0, 0, 0, -1.5, 0, 0, 0, 0))
+    f.Add(int8(2), int8(2), int8(2), false, []byte{0, 0x80, 0xff, 0x7f, 0, 0, 0, 0})
+
+    f.Fuzz(func(t *testing.T, r, c, degree int8, svd bool, data []byte) {
+        backend := ExponentialMethod
+
+        if svd {
+            backend = SingularValueDecomposition
+        }
+
+        unitary, err := UnitaryNDilation(fuzzMatrix(r, c, data), int(degree % 9), WithBackend(backend))
+
+        if (unitary == nil) == (err == nil) {
+            t.Errorf("UnitaryNDilation returned unexpected value: %v, %v", unitary, err)
+        }
+
+        if err == nil {
+            for _, v := range unitary.RawMatrix().Data {
+                if math.IsNaN(v) || math.IsInf(v, 0) {
+                    t.Fatalf("UnitaryNDilation returned non-finite dilation without error: %v", mat.Formatted(unitary))
+                }
+            }
+        }
+    })
+}
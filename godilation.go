@@ -2,18 +2,271 @@
 // It provides a function to calculate a unitary n-dilation for a given matrix contraction and a degree using the gonum library.
 // The recipe to calculate the dilation follows the theory mentioned by Béla Szőkefalvi-Nagy in "Analyse harmonique des opérateurs de l'espace de Hilbert" (1967).
 // The matrix square root needed for the dilation is calculated using the Exponential Method for Matrices.
+// Alternatively, both defect operators can be calculated from a single singular value decomposition.
 package godilation
 
 import (
-    "github.com/acra5y/go-dilation/internal/blockMatrix"
+    "fmt"
+    "github.com/acra5y/go-dilation/blockmatrix"
+    "github.com/acra5y/go-dilation/definiteness"
     "github.com/acra5y/go-dilation/internal/dilation"
-    "github.com/acra5y/go-dilation/internal/positiveDefinite"
-    "github.com/acra5y/go-dilation/internal/squareRoot"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "github.com/acra5y/go-dilation/internal/svdDefect"
+    "github.com/acra5y/go-dilation/sqrtm"
+    "time"
 
     "gonum.org/v1/gonum/mat"
 )
 
-// returns a unitary n-dilation for the given square matrix contraction t or an error, if t is not a contraction or not a square matrix
-func UnitaryNDilation(t *mat.Dense, n int) (*mat.Dense, error) {
-    return dilation.UnitaryNDilation(positiveDefinite.IsPositiveDefinite, squareRoot.Calculate, blockMatrix.NewBlockMatrixFromSquares, t, n)
+// Backend selects how the defect operators of the contraction are calculated
+type Backend int
+
+const (
+    // checks I - TTᵀ for positive definiteness and calculates each defect operator with the Exponential Method for Matrices
+    ExponentialMethod Backend = iota
+    // calculates both defect operators from a single singular value decomposition of T, which also decides whether T is a contraction
+    SingularValueDecomposition
+)
+
+type options struct {
+    backend Backend
+    observer Observer
+    precision uint
+    // whether t is replaced by its nearest contraction of norm at most maxNorm
+    project bool
+    maxNorm float64
+    // whether the determinant of the dilation is made +1
+    special bool
+    // whether the blocks of UnitaryNDilationDecomposed are dilated concurrently
+    parallel bool
+}
+
+// Option configures the calculation of a dilation
+type Option func(*options)
+
+// selects the backend to calculate the defect operators, the default is ExponentialMethod
+func WithBackend(backend Backend) Option {
+    return func(o *options) {
+        o.backend = backend
+    }
+}
+
+/*
+    requests a dilation with determinant +1, i.e. in SO(N) instead of O(N), which UnitaryNDilation does not control otherwise.
+    If the determinant is -1, the last column of the dilation is negated, which is a reflection of the last coordinate of the last block
+    and does not change the compressions of the powers up to the degree.
+*/
+func WithSpecialOrthogonal() Option {
+    return func(o *options) {
+        o.special = true
+    }
+}
+
+func newOptions(opts []Option) *options {
+    o := &options{backend: ExponentialMethod, observer: NopObserver{}, precision: DefaultPrecision}
+
+    for _, opt := range opts {
+        opt(o)
+    }
+
+    return o
+}
+
+var (
+    // returned for a nil contraction or destination
+    ErrNilMatrix = guard.ErrNilMatrix
+    // returned for a contraction without rows or columns
+    ErrEmptyMatrix = guard.ErrEmptyMatrix
+)
+
+// A permutation matrix, which maps the j-th unit vector to the Permutation()[j]-th unit vector and implements mat.Matrix without dense storage
+type Permutation = structured.Permutation
+
+// returned instead of a panic, e.g. of gonum or of a mat.Matrix implementation passed as contraction
+type PanicError = guard.PanicError
+
+// returned if the contraction or an intermediate result contains NaN or ±Inf, Stage tells which calculation produced it
+type NonFiniteError = guard.NonFiniteError
+
+// Stage of a calculation, reported with a NonFiniteError and to an Observer
+type Stage = guard.Stage
+
+const (
+    // the contraction t itself contains a non-finite entry
+    StageInput = guard.StageInput
+    // I - TTᵀ or I - TᵀT overflowed
+    StageDefectSquare = guard.StageDefectSquare
+    // the square root of a squared defect operator
+    StageSquareRoot = guard.StageSquareRoot
+    // the linear solve of the Exponential Method
+    StageSolve = guard.StageSolve
+    // the defect operators calculated from the singular value decomposition
+    StageSingularValueDecomposition = guard.StageSingularValueDecomposition
+    // the check whether I - TTᵀ is positive definite, which decides whether t is a contraction
+    StagePositiveDefiniteCheck = guard.StagePositiveDefiniteCheck
+    // the calculation of both defect operators by the selected backend
+    StageDefects = guard.StageDefects
+    // the assembly of the dilation from its blocks
+    StageBlockAssembly = guard.StageBlockAssembly
+    // the check that a JordanWielandtDilation or HalmosDilation has the structure it promises
+    StageStructureCheck = guard.StageStructureCheck
+)
+
+// returned for a degree that is not positive
+type DegreeError struct {
+    Degree int
+}
+
+func (e *DegreeError) Error() string {
+    return fmt.Sprintf("Unexpected degree: %d (Expecting a positive degree)", e.Degree)
+}
+
+func validate(t mat.Matrix, n int) error {
+    if err := guard.CheckMatrix(t); err != nil {
+        return err
+    }
+
+    if n < 1 {
+        return &DegreeError{Degree: n}
+    }
+
+    return guard.CheckFinite(guard.StageInput, t)
+}
+
+func (o *options) squareRoot(c mat.Matrix) (*mat.Dense, error) {
+    return o.squareRootWith(sqrtm.Exponential, c)
+}
+
+// the Exponential Method may converge to a square root that is not positive definite, the eigendecomposition of a symmetric input does not
+func (o *options) symmetricSquareRoot(c mat.Matrix) (*mat.Dense, error) {
+    return o.squareRootWith(sqrtm.Eigen, c)
+}
+
+func (o *options) squareRootWith(algorithm sqrtm.Algorithm, c mat.Matrix) (*mat.Dense, error) {
+    start := time.Now()
+    sq, result, err := sqrtm.Sqrt(c, &sqrtm.Settings{Algorithm: algorithm, OnIteration: o.observer.Iteration})
+    o.observer.StageDone(StageEvent{Stage: StageSquareRoot, Duration: time.Since(start), SquareRoot: result, Err: err})
+    return sq, err
+}
+
+func (o *options) isPositiveDefinite(eigen definiteness.EigenComputer, candidate mat.Matrix) (bool, error) {
+    start := time.Now()
+    pd, err := definiteness.IsPositiveDefinite(eigen, candidate)
+    o.observer.StageDone(StageEvent{Stage: StagePositiveDefiniteCheck, Duration: time.Since(start), PositiveDefinite: pd, Err: err})
+    return pd, err
+}
+
+func (o *options) defects() func(mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+    return o.defectsWith(o.squareRoot)
+}
+
+// same as defects, but calculates the square roots of the ExponentialMethod backend with sqrt
+func (o *options) defectsWith(sqrt func(mat.Matrix) (*mat.Dense, error)) func(mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+    calculate := svdDefect.Calculate
+
+    if o.backend != SingularValueDecomposition {
+        calculate = dilation.NormalDefects(dilation.SquareRootDefects(o.isPositiveDefinite, sqrt))
+    }
+
+    return func(t mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        start := time.Now()
+        defect, defectOfTransposed, err := calculate(t)
+        o.observer.StageDone(StageEvent{Stage: StageDefects, Duration: time.Since(start), Err: err})
+        return defect, defectOfTransposed, err
+    }
+}
+
+func (o *options) newBlockMatrix(rows [][]mat.Matrix) (*mat.Dense, error) {
+    start := time.Now()
+    d, err := blockmatrix.NewBlockMatrix(rows)
+    o.observer.StageDone(StageEvent{Stage: StageBlockAssembly, Duration: time.Since(start), Err: err})
+    return d, err
+}
+
+func (o *options) blockMatrixTo(dst *mat.Dense, rows [][]mat.Matrix) error {
+    start := time.Now()
+    err := blockmatrix.BlockMatrixTo(dst, rows)
+    o.observer.StageDone(StageEvent{Stage: StageBlockAssembly, Duration: time.Since(start), Err: err})
+    return err
+}
+
+// dilates t with the selected backend and makes its determinant +1, if WithSpecialOrthogonal was passed
+func (o *options) dilate(t mat.Matrix, n int) (*mat.Dense, error) {
+    unitary, err := dilation.UnitaryNDilation(o.defects(), o.newBlockMatrix, t, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    if o.special {
+        dilation.SpecialOrthogonal(unitary)
+    }
+
+    return unitary, nil
+}
+
+// returns a unitary n-dilation for the given matrix contraction t or an error, if t is not a contraction
+// an m times n matrix t results in a dilation of dimension m + n * degree, see the README for its block layout.
+// t can be any mat.Matrix, e.g. a *mat.SymDense, *mat.TriDense, *mat.BandDense, a transpose or a view. For symmetric types only one square root is calculated.
+// Invalid input results in ErrNilMatrix, ErrEmptyMatrix, a *DegreeError or a *NonFiniteError, UnitaryNDilation does not panic
+func UnitaryNDilation(t mat.Matrix, n int, opts ...Option) (unitary *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return nil, err
+    }
+
+    return o.dilate(t, n)
+}
+
+// writes a unitary n-dilation for the given matrix contraction t into dst, following the convention of gonum's receiver methods such as Dense.Mul:
+// an empty dst is resized, a non-empty dst must have the dimension of the dilation and its storage is reused.
+// returns an error, if t is not a contraction or if dst has the wrong dimension, and ErrNilMatrix for a nil dst
+func UnitaryNDilationTo(dst *mat.Dense, t mat.Matrix, n int, opts ...Option) (err error) {
+    defer guard.Recover(&err)
+
+    if dst == nil {
+        return ErrNilMatrix
+    }
+
+    if err := validate(t, n); err != nil {
+        return err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return err
+    }
+
+    if err := dilation.UnitaryNDilationTo(o.defects(), o.blockMatrixTo, dst, t, n); err != nil {
+        return err
+    }
+
+    if o.special {
+        dilation.SpecialOrthogonal(dst)
+    }
+
+    return nil
+}
+
+// same as UnitaryNDilation, but returns the dilation partitioned into its blocks,
+// so that e.g. Block(0, 0) is t and Block(1, 0) is the defect operator D_T = sqrt(I - TᵀT)
+func UnitaryNDilationBlockMatrix(t mat.Matrix, n int, opts ...Option) (*blockmatrix.BlockMatrix, error) {
+    unitary, err := UnitaryNDilation(t, n, opts...)
+
+    if err != nil {
+        return nil, err
+    }
+
+    m, c := t.Dims()
+    rows, cols := dilation.Partition(m, c, n)
+    return blockmatrix.NewFromDense(unitary, rows, cols)
 }
@@ -2,6 +2,9 @@ package godilation
 
 import (
     "fmt"
+    "github.com/acra5y/go-dilation/blockmatrix"
+    "math"
+    "math/rand"
     "gonum.org/v1/gonum/mat"
     "reflect"
     "testing"
@@ -48,9 +51,596 @@ func TestUnitaryNDilation(t *testing.T) {
                 t.Errorf("Wrong result, got: %v, want: %v", value, table.expectedValue)
             }
 
-            if table.expectedValue != nil && !mat.Equal(value, table.expectedValue) {
+            if table.expectedValue != nil && !mat.EqualApprox(value, table.expectedValue, 1e-10) {
                 t.Errorf("Wrong result, got: %v, want: %v", value, table.expectedValue)
             }
         })
     }
 }
+
+func TestUnitaryNDilationTo(t *testing.T) {
+    contraction := mat.NewDense(2, 2, []float64{0.5,0,0,0.2,})
+    expected, err := UnitaryNDilation(contraction, 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    tables := []struct {
+        desc string
+        dst *mat.Dense
+        value *mat.Dense
+        expectedValue *mat.Dense
+        expectedErr error
+    }{
+        {
+            desc: "resizes an empty destination",
+            dst: &mat.Dense{},
+            value: contraction,
+            expectedValue: expected,
+            expectedErr: nil,
+        },
+        {
+            desc: "reuses a destination with matching dimension",
+            dst: mat.NewDense(6, 6, nil),
+            value: contraction,
+            expectedValue: expected,
+            expectedErr: nil,
+        },
+        {
+            desc: "return an error if destination has wrong dimension",
+            dst: mat.NewDense(4, 4, nil),
+            value: contraction,
+            expectedValue: mat.NewDense(4, 4, nil),
+            expectedErr: fmt.Errorf("Unexpected dimension of destination: (4, 4) (Expecting (6, 6))"),
+        },
+        {
+            desc: "return an error if matrix is not a contraction",
+            dst: mat.NewDense(6, 6, nil),
+            value: mat.NewDense(2, 2, []float64{0.5,0,0,2,}),
+            expectedValue: mat.NewDense(6, 6, nil),
+            expectedErr: fmt.Errorf("Input is not a contraction"),
+        },
+    }
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            err := UnitaryNDilationTo(table.dst, table.value, 2)
+
+            if !reflect.DeepEqual(err, table.expectedErr) {
+                t.Errorf("Wrong error, got: %v, want: %v", err, table.expectedErr)
+            }
+
+            if !mat.Equal(table.dst, table.expectedValue) {
+                t.Errorf("Wrong result, got: %v, want: %v", table.dst, table.expectedValue)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationToReusedBuffer(t *testing.T) {
+    dst := mat.NewDense(6, 6, nil)
+    raw := dst.RawMatrix().Data
+
+    for _, value := range []*mat.Dense{mat.NewDense(2, 2, []float64{0.5,0,0,0.2,}), mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,})} {
+        expected, _ := UnitaryNDilation(value, 2)
+
+        if err := UnitaryNDilationTo(dst, value, 2); err != nil {
+            t.Fatalf("Unexpected error: %v", err)
+        }
+
+        if !mat.Equal(dst, expected) {
+            t.Errorf("Wrong result, got: %v, want: %v", dst, expected)
+        }
+
+        if &dst.RawMatrix().Data[0] != &raw[0] {
+            t.Errorf("Storage of destination was not reused")
+        }
+    }
+}
+
+func TestUnitaryNDilationInputTypes(t *testing.T) {
+    values := mat.NewDense(3, 3, []float64{
+        0.5, 0.1, 0.2,
+        0.1, 0.3, 0,
+        0.2, 0, 0.4,
+    })
+    tables := []struct {
+        desc string
+        value mat.Matrix
+    }{
+        {desc: "symmetric matrix", value: mat.NewSymDense(3, []float64{0.5, 0.1, 0.2, 0.1, 0.3, 0, 0.2, 0, 0.4})},
+        {desc: "triangular matrix", value: mat.NewTriDense(3, mat.Upper, []float64{0.5, 0.1, 0.2, 0, 0.3, 0.1, 0, 0, 0.4})},
+        {desc: "band matrix", value: mat.NewBandDense(3, 3, 1, 0, []float64{0, 0.5, 0.2, 0.3, 0.1, 0.4})},
+        {desc: "diagonal matrix", value: mat.NewDiagDense(3, []float64{0.5, 0.3, 0.4})},
+        {desc: "transpose", value: mat.NewDense(3, 3, []float64{0.5, 0.2, 0, 0.1, 0.3, 0, 0.2, 0.1, 0.4}).T()},
+        {desc: "view", value: values.Slice(0, 2, 0, 2)},
+    }
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            expected, err := UnitaryNDilation(mat.DenseCopyOf(table.value), 2)
+
+            if err != nil {
+                t.Fatalf("Unexpected error for dense copy: %v", err)
+            }
+
+            value, err := UnitaryNDilation(table.value, 2)
+
+            if err != nil {
+                t.Errorf("Unexpected error: %v", err)
+            }
+
+            if !mat.EqualApprox(value, expected, 1e-12) {
+                t.Errorf("Wrong result, got: %v, want: %v", value, expected)
+            }
+        })
+    }
+}
+
+func isNDilation(t *testing.T, unitary *mat.Dense, value mat.Matrix, degree int, tol float64) {
+    t.Helper()
+    d, _ := unitary.Dims()
+    n, _ := value.Dims()
+
+    product := mat.NewDense(d, d, nil)
+    product.Mul(unitary.T(), unitary)
+    eye := mat.NewDiagDense(d, nil)
+    for i := 0; i < d; i++ {
+        eye.SetDiag(i, 1)
+    }
+
+    if !mat.EqualApprox(product, eye, tol) {
+        t.Errorf("Result is not unitary, UᵀU = %v", mat.Formatted(product))
+    }
+
+    power := mat.DenseCopyOf(unitary)
+    expected := mat.DenseCopyOf(value)
+    for k := 1; k <= degree; k++ {
+        if !mat.EqualApprox(power.Slice(0, n, 0, n), expected, tol) {
+            t.Errorf("Wrong compression of power %d, got: %v, want: %v", k, mat.Formatted(power.Slice(0, n, 0, n)), mat.Formatted(expected))
+        }
+        power.Mul(power, unitary)
+        expected.Mul(expected, value)
+    }
+}
+
+func TestUnitaryNDilationBackends(t *testing.T) {
+    backends := []struct {
+        desc string
+        backend Backend
+        tol float64
+    }{
+        {desc: "exponential method", backend: ExponentialMethod, tol: 1e-5},
+        {desc: "singular value decomposition", backend: SingularValueDecomposition, tol: 1e-12},
+    }
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+    }{
+        {desc: "diagonal matrix", value: mat.NewDense(2, 2, []float64{0.5,0,0,0.2,}), degree: 2},
+        {desc: "non normal matrix", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 1},
+        {desc: "non normal matrix with degree > 1", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 3},
+        {desc: "3x3 matrix", value: mat.NewDense(3, 3, []float64{0.1,0,0.3,0, 0.12, 0.1412,0, 0, 0.12}), degree: 2},
+        {desc: "symmetric matrix", value: mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,}), degree: 2},
+    }
+    for _, backend := range backends {
+        for _, table := range tables {
+            backend, table := backend, table
+            t.Run(backend.desc + " " + table.desc, func(t *testing.T) {
+                t.Parallel()
+                unitary, err := UnitaryNDilation(table.value, table.degree, WithBackend(backend.backend))
+
+                if err != nil {
+                    t.Fatalf("Unexpected error: %v", err)
+                }
+
+                isNDilation(t, unitary, table.value, table.degree, backend.tol)
+            })
+        }
+    }
+}
+
+func TestUnitaryNDilationSingularValueDecompositionNoContraction(t *testing.T) {
+    value, err := UnitaryNDilation(mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,}), 2, WithBackend(SingularValueDecomposition))
+    expectedErr := fmt.Errorf("Input is not a contraction")
+
+    if !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("Wrong error, got: %v, want: %v", err, expectedErr)
+    }
+
+    if value != nil {
+        t.Errorf("Wrong result, got: %v, want: %v", value, nil)
+    }
+}
+
+func randomContraction(n int, norm float64) *mat.Dense {
+    rnd := rand.New(rand.NewSource(1))
+    data := make([]float64, n * n)
+    for i := range data {
+        data[i] = rnd.NormFloat64()
+    }
+    t := mat.NewDense(n, n, data)
+    t.Scale(norm / mat.Norm(t, 2), t)
+    return t
+}
+
+func BenchmarkUnitaryNDilation(b *testing.B) {
+    backends := []struct {
+        desc string
+        backend Backend
+    }{
+        {desc: "ExponentialMethod", backend: ExponentialMethod},
+        {desc: "SingularValueDecomposition", backend: SingularValueDecomposition},
+    }
+    for _, backend := range backends {
+        for _, n := range []int{2, 8, 32} {
+            value := randomContraction(n, 0.5)
+            b.Run(fmt.Sprintf("%s/n=%d", backend.desc, n), func(b *testing.B) {
+                for i := 0; i < b.N; i++ {
+                    if _, err := UnitaryNDilation(value, 2, WithBackend(backend.backend)); err != nil {
+                        b.Fatal(err)
+                    }
+                }
+            })
+        }
+    }
+
+    for _, n := range []int{2, 8, 32} {
+        value := normalContraction(n, 0.5)
+        b.Run(fmt.Sprintf("ExponentialMethod/normal/n=%d", n), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                if _, err := UnitaryNDilation(value, 2); err != nil {
+                    b.Fatal(err)
+                }
+            }
+        })
+    }
+}
+
+// returns norm times an orthogonal matrix with rotation blocks, which is normal but not symmetric
+func normalContraction(n int, norm float64) *mat.Dense {
+    var qr mat.QR
+    qr.Factorize(randomContraction(n, 1))
+
+    var q mat.Dense
+    qr.QTo(&q)
+
+    rotation := mat.NewDense(n, n, nil)
+    for i := 0; i + 1 < n; i += 2 {
+        rotation.Set(i, i, 0.6)
+        rotation.Set(i, i + 1, 0.8)
+        rotation.Set(i + 1, i, -0.8)
+        rotation.Set(i + 1, i + 1, 0.6)
+    }
+    if n % 2 == 1 {
+        rotation.Set(n - 1, n - 1, -1)
+    }
+
+    t := mat.NewDense(n, n, nil)
+    t.Product(&q, rotation, q.T())
+    t.Scale(norm, t)
+    return t
+}
+
+func TestUnitaryNDilationNormal(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+    }{
+        {desc: "scaled orthogonal matrix", value: normalContraction(5, 0.9), degree: 3},
+        {desc: "symmetric matrix", value: mat.NewSymDense(3, []float64{0.3,0.1,0.2, 0.1,0.4,0, 0.2,0,-0.5,}), degree: 2},
+        {desc: "symmetric matrix close to the boundary", value: mat.NewDiagDense(2, []float64{1 - 1e-9, -0.5,}), degree: 2},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            unitary, err := UnitaryNDilation(table.value, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            isNDilation(t, unitary, table.value, table.degree, 1e-13)
+        })
+    }
+
+    if _, err := UnitaryNDilation(normalContraction(4, 1), 1); !reflect.DeepEqual(err, fmt.Errorf("Input is not a contraction")) {
+        t.Errorf("Wrong error for a normal matrix of norm 1, got: %v", err)
+    }
+}
+
+func contractionWithDefectEigenvalues(eigenvalues []float64) *mat.Dense {
+    n := len(eigenvalues)
+    singularValues := make([]float64, n)
+    for i, v := range eigenvalues {
+        singularValues[i] = math.Sqrt(1 - v)
+    }
+    var qr mat.QR
+    var w, v mat.Dense
+    qr.Factorize(randomContraction(n, 1))
+    qr.QTo(&w)
+    qr.Factorize(randomContraction(n, 0.5).T())
+    qr.QTo(&v)
+    t := mat.NewDense(n, n, nil)
+    t.Product(&w, mat.NewDiagDense(n, singularValues), v.T())
+    return t
+}
+
+func TestUnitaryNDilationIllConditionedDefects(t *testing.T) {
+    // the eigenvalues of the defect operators span 1e-10 to 1
+    eigenvalues := []float64{1e-10, 1e-8, 1e-6, 1e-4, 1e-2, 1}
+    value := contractionWithDefectEigenvalues(eigenvalues)
+
+    unitary, err := UnitaryNDilation(value, 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    isNDilation(t, unitary, value, 2, 1e-9)
+}
+
+// returns block (i, j) of b and fails the test, if Block returns an error
+func mustBlock(t *testing.T, b *blockmatrix.BlockMatrix, i, j int) *mat.Dense {
+    t.Helper()
+    block, err := b.Block(i, j)
+
+    if err != nil {
+        t.Fatalf("Block returned unexpected error: %v", err)
+    }
+
+    return block
+}
+
+func TestUnitaryNDilationBlockMatrix(t *testing.T) {
+    value := randomContraction(3, 0.8)
+    b, err := UnitaryNDilationBlockMatrix(value, 3, WithBackend(SingularValueDecomposition))
+
+    if err != nil {
+        t.Fatalf("UnitaryNDilationBlockMatrix returned unexpected error: %v", err)
+    }
+
+    if r, c := b.BlockDims(); r != 4 || c != 4 {
+        t.Errorf("Wrong block dimension, got: (%d, %d), want: (4, 4)", r, c)
+    }
+
+    isNDilation(t, b.Dense(), value, 3, 1e-10)
+
+    var defectSquared, defectOfTransposedSquared mat.Dense
+    defectSquared.Mul(mustBlock(t, b, 1, 0), mustBlock(t, b, 1, 0))
+    defectOfTransposedSquared.Mul(mustBlock(t, b, 0, 3), mustBlock(t, b, 0, 3))
+
+    var expectedDefectSquared, expectedDefectOfTransposedSquared mat.Dense
+    expectedDefectSquared.Mul(value.T(), value)
+    expectedDefectSquared.Sub(mat.NewDiagDense(3, []float64{1, 1, 1}), &expectedDefectSquared)
+    expectedDefectOfTransposedSquared.Mul(value, value.T())
+    expectedDefectOfTransposedSquared.Sub(mat.NewDiagDense(3, []float64{1, 1, 1}), &expectedDefectOfTransposedSquared)
+
+    if !mat.Equal(mustBlock(t, b, 0, 0), value) {
+        t.Errorf("Wrong block (0, 0), got: %v, want: %v", mustBlock(t, b, 0, 0), value)
+    }
+
+    if !mat.EqualApprox(&defectSquared, &expectedDefectSquared, 1e-10) {
+        t.Errorf("Block (1, 0) is not D_T, got square: %v, want: %v", &defectSquared, &expectedDefectSquared)
+    }
+
+    if !mat.EqualApprox(&defectOfTransposedSquared, &expectedDefectOfTransposedSquared, 1e-10) {
+        t.Errorf("Block (0, 3) is not D_{Tᵀ}, got square: %v, want: %v", &defectOfTransposedSquared, &expectedDefectOfTransposedSquared)
+    }
+
+    if !mat.Equal(mustBlock(t, b, 2, 1), mat.NewDiagDense(3, []float64{1, 1, 1})) {
+        t.Errorf("Wrong block (2, 1), got: %v", mustBlock(t, b, 2, 1))
+    }
+}
+
+func TestUnitaryNDilationRectangular(t *testing.T) {
+    backends := []struct {
+        desc string
+        backend Backend
+        tol float64
+    }{
+        {desc: "exponential method", backend: ExponentialMethod, tol: 1e-5},
+        {desc: "singular value decomposition", backend: SingularValueDecomposition, tol: 1e-12},
+    }
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        degree int
+        dim int
+    }{
+        {desc: "wide matrix", value: mat.NewDense(2, 3, []float64{0.5,0.1,0,0,0.2,0.3,}), degree: 1, dim: 5},
+        {desc: "tall matrix", value: mat.NewDense(3, 2, []float64{0.5,0.1,0,0.2,0.3,0,}), degree: 1, dim: 5},
+        {desc: "wide matrix with degree > 1", value: mat.NewDense(2, 3, []float64{0.5,0.1,0,0,0.2,0.3,}), degree: 3, dim: 11},
+        {desc: "tall matrix with degree > 1", value: mat.NewDense(3, 2, []float64{0.5,0.1,0,0.2,0.3,0,}), degree: 2, dim: 7},
+    }
+    for _, backend := range backends {
+        for _, table := range tables {
+            backend, table := backend, table
+            t.Run(backend.desc + " " + table.desc, func(t *testing.T) {
+                t.Parallel()
+                b, err := UnitaryNDilationBlockMatrix(table.value, table.degree, WithBackend(backend.backend))
+
+                if err != nil {
+                    t.Fatalf("Unexpected error: %v", err)
+                }
+
+                if r, c := b.Dims(); r != table.dim || c != table.dim {
+                    t.Errorf("Wrong dimension, got: (%d, %d), want: (%d, %d)", r, c, table.dim, table.dim)
+                }
+
+                if deviation := unitarityDeviation(b.Dense()); deviation > backend.tol {
+                    t.Errorf("Dilation is not unitary, ‖UᵀU − I‖_F = %v", deviation)
+                }
+
+                if !mat.Equal(mustBlock(t, b, 0, 0), table.value) {
+                    t.Errorf("Wrong block (0, 0), got: %v, want: %v", mustBlock(t, b, 0, 0), table.value)
+                }
+
+                var dst mat.Dense
+
+                if err := UnitaryNDilationTo(&dst, table.value, table.degree, WithBackend(backend.backend)); err != nil || !mat.Equal(&dst, b) {
+                    t.Errorf("UnitaryNDilationTo wrote wrong value, got: %v, %v, want: %v, nil", &dst, err, b.Dense())
+                }
+            })
+        }
+    }
+}
+
+func TestSquareEntryPointsRejectRectangular(t *testing.T) {
+    value := mat.NewDense(1, 2, []float64{0.5,0,})
+    expected := fmt.Errorf("Matrix does not have square dimension")
+    tables := []struct {
+        desc string
+        run func() error
+    }{
+        {desc: "UnitaryNDilationBigFloat", run: func() error {
+            _, _, err := UnitaryNDilationBigFloat(value, 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationRat", run: func() error {
+            _, _, err := UnitaryNDilationRat(ratMatrix(1, 2, "1/2", "0"), 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationVerified", run: func() error {
+            _, err := UnitaryNDilationVerified(value, 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationDerivative", run: func() error {
+            _, err := UnitaryNDilationDerivative(value, value, 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationAdjoint", run: func() error {
+            _, err := UnitaryNDilationAdjoint(value, mat.NewDense(3, 3, nil), 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationDecomposed", run: func() error {
+            _, _, err := UnitaryNDilationDecomposed(value, 1)
+            return err
+        }},
+        {desc: "UnitaryNDilationKronecker", run: func() error {
+            _, err := UnitaryNDilationKronecker(value, mat.NewDense(1, 1, []float64{0.5}), 1)
+            return err
+        }},
+    }
+
+    for _, table := range tables {
+        if err := table.run(); !reflect.DeepEqual(err, expected) {
+            t.Errorf("%s returned wrong error, got: %v, want: %v", table.desc, err, expected)
+        }
+    }
+}
+
+// a matrix that panics on every access of an entry
+type panickingMatrix struct{}
+
+func (panickingMatrix) Dims() (r, c int) { return 2, 2 }
+
+func (panickingMatrix) At(i, j int) float64 { panic("no entries") }
+
+func (m panickingMatrix) T() mat.Matrix { return mat.Transpose{Matrix: m} }
+
+func TestUnitaryNDilationInvalidInput(t *testing.T) {
+    var nilDense *mat.Dense
+
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+        err error
+    }{
+        {desc: "nil", value: nil, degree: 2, err: ErrNilMatrix},
+        {desc: "typed nil", value: nilDense, degree: 2, err: ErrNilMatrix},
+        {desc: "empty matrix", value: &mat.Dense{}, degree: 2, err: ErrEmptyMatrix},
+        {desc: "degree 0", value: mat.NewDense(1, 1, []float64{0.5}), degree: 0, err: &DegreeError{Degree: 0}},
+        {desc: "negative degree", value: mat.NewDense(1, 1, []float64{0.5}), degree: -3, err: &DegreeError{Degree: -3}},
+        {desc: "panicking matrix", value: panickingMatrix{}, degree: 2, err: &PanicError{Value: "no entries"}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            for _, backend := range []Backend{ExponentialMethod, SingularValueDecomposition} {
+                value, err := UnitaryNDilation(table.value, table.degree, WithBackend(backend))
+
+                if !reflect.DeepEqual(err, table.err) || value != nil {
+                    t.Errorf("UnitaryNDilation returned wrong value, got: %v, %v, want: nil, %v", value, err, table.err)
+                }
+
+                dst := &mat.Dense{}
+
+                if err := UnitaryNDilationTo(dst, table.value, table.degree, WithBackend(backend)); !reflect.DeepEqual(err, table.err) {
+                    t.Errorf("UnitaryNDilationTo returned wrong error, got: %v, want: %v", err, table.err)
+                }
+
+                if b, err := UnitaryNDilationBlockMatrix(table.value, table.degree, WithBackend(backend)); !reflect.DeepEqual(err, table.err) || b != nil {
+                    t.Errorf("UnitaryNDilationBlockMatrix returned wrong value, got: %v, %v, want: nil, %v", b, err, table.err)
+                }
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationToNilDestination(t *testing.T) {
+    err := UnitaryNDilationTo(nil, mat.NewDense(1, 1, []float64{0.5}), 2)
+
+    if err != ErrNilMatrix {
+        t.Errorf("Wrong error, got: %v, want: %v", err, ErrNilMatrix)
+    }
+}
+
+func TestUnitaryNDilationNonFinite(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        backends []Backend
+        expected error
+    }{
+        {
+            desc: "NaN in input",
+            value: mat.NewDense(2, 2, []float64{0.5, math.NaN(), 0, 0.5}),
+            backends: []Backend{ExponentialMethod, SingularValueDecomposition},
+            expected: &NonFiniteError{Stage: StageInput, Row: 0, Col: 1},
+        },
+        {
+            desc: "Inf in input",
+            value: mat.NewDense(2, 2, []float64{0.5, 0, 0, math.Inf(-1)}),
+            backends: []Backend{ExponentialMethod, SingularValueDecomposition},
+            expected: &NonFiniteError{Stage: StageInput, Row: 1, Col: 1, Value: math.Inf(-1)},
+        },
+        {
+            desc: "overflow of the squared defect operator",
+            value: mat.NewDense(2, 2, []float64{1e200, 0, 0, 0}),
+            backends: []Backend{ExponentialMethod},
+            expected: &NonFiniteError{Stage: StageDefectSquare, Row: 0, Col: 0, Value: math.Inf(-1)},
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            for _, backend := range table.backends {
+                unitary, err := UnitaryNDilation(table.value, 2, WithBackend(backend))
+                e, ok := err.(*NonFiniteError)
+
+                if !ok || unitary != nil {
+                    t.Fatalf("UnitaryNDilation returned wrong value, got: %v, %v, want: nil, %v", unitary, err, table.expected)
+                }
+
+                expected := table.expected.(*NonFiniteError)
+
+                if e.Stage != expected.Stage || e.Row != expected.Row || e.Col != expected.Col {
+                    t.Errorf("Wrong error, got: %v, want: %v", err, table.expected)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,94 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/bigmat"
+    "math/big"
+)
+
+type bigIsPositiveDefinite func(*bigmat.Dense) (bool, error)
+
+type bigSquareRoot func(*bigmat.Dense) (*bigmat.Dense, error)
+
+type newBigBlockMatrixFromSquares func([][]*bigmat.Dense) (*bigmat.Dense, error)
+
+// same as defectOperators in the precision of t
+type bigDefectOperators func(*bigmat.Dense) (defect, defectOfTransposed *bigmat.Dense, err error)
+
+var bigMinusOne = big.NewFloat(-1)
+
+// returns I - TTᵀ in the precision of t
+func bigDefectOperatorSquared(t *bigmat.Dense) *bigmat.Dense {
+    n, _ := t.Dims()
+    return bigmat.Sub(bigmat.Identity(n, t.Prec()), bigmat.Mul(t, t.T()))
+}
+
+// Same as SquareRootDefects in the precision of t. Both square roots are always calculated, as bigmat.Dense does not tell whether t is symmetric.
+func BigSquareRootDefects(isPD bigIsPositiveDefinite, sqrt bigSquareRoot) bigDefectOperators {
+    return func(t *bigmat.Dense) (*bigmat.Dense, *bigmat.Dense, error) {
+        defectSquaredOfTransposed := bigDefectOperatorSquared(t)
+
+        if pd, err := isPD(defectSquaredOfTransposed); err != nil || !pd {
+            return nil, nil, fmt.Errorf("Input is not a contraction")
+        }
+
+        defectOfTransposed, err := sqrt(defectSquaredOfTransposed)
+
+        if err != nil {
+            return nil, nil, err
+        }
+
+        defect, err := sqrt(bigDefectOperatorSquared(t.T()))
+
+        if err != nil {
+            return nil, nil, err
+        }
+
+        return defect, defectOfTransposed, nil
+    }
+}
+
+// Same as UnitaryNDilation in the precision of t, with the blocks laid out as described for unitaryNDilationBlocks.
+func UnitaryNDilationBig(defects bigDefectOperators, newBlockMatrix newBigBlockMatrixFromSquares, t *bigmat.Dense, degree int) (*bigmat.Dense, error) {
+    m, n := t.Dims()
+
+    if m != n {
+        return nil, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    defect, defectOfTransposed, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    return newBlockMatrix(bigUnitaryNDilationBlocks(t, defect, defectOfTransposed, degree))
+}
+
+// returns the blocks of the dilation as described for unitaryNDilationBlocks
+func bigUnitaryNDilationBlocks(t, defect, defectOfTransposed *bigmat.Dense, degree int) [][]*bigmat.Dense {
+    m, n := t.Dims()
+    blockDim := degree + 1
+    rows := make([][]*bigmat.Dense, blockDim)
+    zero := bigmat.NewDense(m, n, t.Prec())
+    identity := bigmat.Identity(m, t.Prec())
+
+    for i := range rows {
+        rows[i] = make([]*bigmat.Dense, blockDim)
+
+        for j := range rows[i] {
+            if i > 1 && j == i - 1 {
+                rows[i][j] = identity
+            } else {
+                rows[i][j] = zero
+            }
+        }
+    }
+
+    rows[0][0] = t
+    rows[0][blockDim - 1] = defectOfTransposed
+    rows[1][0] = defect
+    rows[1][blockDim - 1] = bigmat.Scale(bigMinusOne, t.T())
+
+    return rows
+}
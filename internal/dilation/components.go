@@ -0,0 +1,202 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "gonum.org/v1/gonum/mat"
+    "sync"
+)
+
+// calls f for k = 0, ..., n - 1 and returns the error of the smallest k for which f failed
+type forEachComponent func(n int, f func(k int) error) error
+
+// Calls f for one component after another on the calling goroutine and stops at the first error
+func Sequential(n int, f func(k int) error) error {
+    for k := 0; k < n; k++ {
+        if err := f(k); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// Calls f for all components concurrently, one goroutine per component. A panic of f is returned as *guard.PanicError of its component,
+// as it could not be recovered by the caller on another goroutine
+func Parallel(n int, f func(k int) error) error {
+    errs := make([]error, n)
+    var wg sync.WaitGroup
+    wg.Add(n)
+
+    for k := 0; k < n; k++ {
+        go func(k int) {
+            defer wg.Done()
+            defer guard.Recover(&errs[k])
+            errs[k] = f(k)
+        }(k)
+    }
+
+    wg.Wait()
+
+    for _, err := range errs {
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// connects all indices to the first one
+func connect(parent []int, indices []int) {
+    for _, j := range indices[1:] {
+        if ri, rj := find(parent, indices[0]), find(parent, j); ri != rj {
+            parent[rj] = ri
+        }
+    }
+}
+
+// returns the root of i and compresses the path to it
+func find(parent []int, i int) int {
+    for parent[i] != i {
+        parent[i] = parent[parent[i]]
+        i = parent[i]
+    }
+    return i
+}
+
+/*
+    Returns the connected components of the combined sparsity patterns of the square matrix t, TᵀT and TTᵀ, where i and j are connected
+    if T_ij or T_ji is not zero, or if the structural pattern of TᵀT or TTᵀ has an entry at (i, j), i.e. if columns i and j of t share a row
+    or rows i and j share a column. The latter are connected through the shared index already, but are included explicitly, as the defect
+    operators need TᵀT and TTᵀ to be block diagonal as well. Each component lists its indices in ascending order and the components are ordered
+    by their smallest index. Only symmetric permutations are detected, as only those keep the compressions of the dilation the powers of t.
+*/
+func Components(t mat.Matrix) [][]int {
+    n, _ := t.Dims()
+    parent := make([]int, n)
+
+    for i := range parent {
+        parent[i] = i
+    }
+
+    columnsOfRow := make([][]int, n)
+    rowsOfColumn := make([][]int, n)
+
+    for i := 0; i < n; i++ {
+        for j := 0; j < n; j++ {
+            if t.At(i, j) != 0 {
+                if ri, rj := find(parent, i), find(parent, j); ri != rj {
+                    parent[rj] = ri
+                }
+
+                columnsOfRow[i] = append(columnsOfRow[i], j)
+                rowsOfColumn[j] = append(rowsOfColumn[j], i)
+            }
+        }
+    }
+
+    // the columns of a row are connected in the pattern of TᵀT, the rows of a column in the pattern of TTᵀ
+    for i := 0; i < n; i++ {
+        if len(columnsOfRow[i]) > 1 {
+            connect(parent, columnsOfRow[i])
+        }
+
+        if len(rowsOfColumn[i]) > 1 {
+            connect(parent, rowsOfColumn[i])
+        }
+    }
+
+    indices := make(map[int][]int)
+    roots := []int{}
+
+    for i := 0; i < n; i++ {
+        r := find(parent, i)
+
+        if _, ok := indices[r]; !ok {
+            roots = append(roots, r)
+        }
+
+        indices[r] = append(indices[r], i)
+    }
+
+    // the indices are visited in ascending order, so each component is sorted and the roots are found in the order of the smallest index of their component
+    components := make([][]int, len(roots))
+
+    for k, r := range roots {
+        components[k] = indices[r]
+    }
+
+    return components
+}
+
+// returns the principal submatrix of t with the rows and columns in indices
+func principalSubmatrix(t mat.Matrix, indices []int) *mat.Dense {
+    s := len(indices)
+    sub := mat.NewDense(s, s, nil)
+
+    for p, i := range indices {
+        for q, j := range indices {
+            sub.Set(p, q, t.At(i, j))
+        }
+    }
+
+    return sub
+}
+
+/*
+    Returns the unitary degree-dilation of the square matrix t, calculated by dilate for the principal submatrix of each of its Components
+    as scheduled by forEach,
+    together with the dilations of the components and the permutation matrix P of perm, so that the dilation U of t is Pᵀ B P,
+    i.e. U_ij = B_{perm[i], perm[j]}, for the direct sum B of the dilations of the components. As the defect operators of a direct sum are the direct sums of the defect operators,
+    U has the same layout as the dilation of t calculated at once, but only square roots of the dimension of the components are calculated.
+*/
+func UnitaryNDilationDecomposed(dilate func(mat.Matrix) (*mat.Dense, error), forEach forEachComponent, t mat.Matrix, degree int) (unitary *mat.Dense, components [][]int, blocks []*mat.Dense, permutation *structured.Permutation, err error) {
+    m, n := t.Dims()
+
+    if m != n {
+        return nil, nil, nil, nil, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    components = Components(t)
+    blocks = make([]*mat.Dense, len(components))
+    d := m * (degree + 1)
+    perm := make([]int, d)
+    unitary = mat.NewDense(d, d, nil)
+    offset := 0
+
+    err = forEach(len(components), func(k int) (err error) {
+        blocks[k], err = dilate(principalSubmatrix(t, components[k]))
+        return err
+    })
+
+    if err != nil {
+        return nil, nil, nil, nil, err
+    }
+
+    for k, indices := range components {
+        s := len(indices)
+
+        // row a s + p of the dilation of the component is row a m + indices[p] of the dilation of t
+        for a := 0; a <= degree; a++ {
+            for p, i := range indices {
+                perm[a * m + i] = offset + a * s + p
+
+                for b := 0; b <= degree; b++ {
+                    for q, j := range indices {
+                        unitary.Set(a * m + i, b * m + j, blocks[k].At(a * s + p, b * s + q))
+                    }
+                }
+            }
+        }
+
+        offset += s * (degree + 1)
+    }
+
+    if permutation, err = structured.NewPermutation(perm); err != nil {
+        return nil, nil, nil, nil, err
+    }
+
+    return unitary, components, blocks, permutation, nil
+}
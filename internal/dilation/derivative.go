@@ -0,0 +1,154 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "gonum.org/v1/gonum/mat"
+)
+
+// returns the derivative of the square root sq in direction e, the solution X of sq X + X sq = e, which is self-adjoint in e
+type squareRootDerivative func(sq mat.Symmetric, e mat.Matrix) (*mat.Dense, error)
+
+// returns the symmetric part of a defect operator, which is symmetric up to rounding errors
+func symmetricPart(d mat.Matrix) *mat.SymDense {
+    n, _ := d.Dims()
+    sym := mat.NewSymDense(n, nil)
+
+    for i := 0; i < n; i++ {
+        for j := i; j < n; j++ {
+            sym.SetSym(i, j, (d.At(i, j) + d.At(j, i)) / 2)
+        }
+    }
+
+    return sym
+}
+
+// returns -(abᵀ + baᵀ), the derivative of I - aaᵀ in direction b for a = T and of I - aᵀa for a = Tᵀ
+func defectSquaredDerivative(a, b mat.Matrix) *mat.Dense {
+    n, _ := a.Dims()
+    d := mat.NewDense(n, n, nil)
+    d.Mul(a, b.T())
+    d.Add(d, d.T())
+    d.Scale(-1, d)
+    return d
+}
+
+func checkDirection(t, e mat.Matrix, name string) error {
+    m, n := t.Dims()
+
+    if m != n {
+        return fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    if r, c := e.Dims(); r != m || c != n {
+        return fmt.Errorf("Unexpected dimension of %s: (%d, %d) (Expecting (%d, %d))", name, r, c, m, n)
+    }
+
+    return nil
+}
+
+/*
+    Returns the directional derivative dU[T; E] of the dilation. Its blocks are laid out as described for unitaryNDilationBlocks:
+    E in place of T, -Eᵀ in place of -Tᵀ, zero blocks in place of the block shift and the derivatives of the defect operators,
+    which solve D_{Tᵀ} X + X D_{Tᵀ} = -(ETᵀ + TEᵀ) and D_T Y + Y D_T = -(EᵀT + TᵀE).
+*/
+func UnitaryNDilationDerivative(defects defectOperators, derivative squareRootDerivative, newBlockMatrix newBlockMatrixFromSquares, t, e mat.Matrix, degree int) (*mat.Dense, error) {
+    if err := checkDirection(t, e, "direction"); err != nil {
+        return nil, err
+    }
+
+    defect, defectOfTransposed, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    dDefectOfTransposed, err := derivative(symmetricPart(defectOfTransposed), defectSquaredDerivative(t, e))
+
+    if err != nil {
+        return nil, err
+    }
+
+    dDefect, err := derivative(symmetricPart(defect), defectSquaredDerivative(t.T(), e.T()))
+
+    if err != nil {
+        return nil, err
+    }
+
+    m, _ := t.Dims()
+    blockDim := degree + 1
+    zero := structured.NewZero(m, m)
+    rows := make([][]mat.Matrix, blockDim)
+
+    for i := range rows {
+        rows[i] = make([]mat.Matrix, blockDim)
+
+        for j := range rows[i] {
+            rows[i][j] = zero
+        }
+    }
+
+    rows[0][0] = e
+    rows[0][blockDim - 1] = dDefectOfTransposed
+    rows[1][0] = dDefect
+    rows[1][blockDim - 1] = negativeTranspose(e)
+
+    return newBlockMatrix(rows)
+}
+
+/*
+    Returns the adjoint of the derivative in direction g, which is the gradient ∇_T ⟨G, U(T)⟩ for a gradient g of the dilation.
+    With the blocks G_00, G_{0n}, G_10 and G_{1n} of g at the positions of T, D_{Tᵀ}, D_T and -Tᵀ and the self-adjoint derivatives
+    W = L_{D_{Tᵀ}}(G_{0n}) and V = L_{D_T}(G_10) of the square roots, it is G_00 - G_{1n}ᵀ - (W + Wᵀ)T - T(V + Vᵀ).
+*/
+func UnitaryNDilationAdjoint(defects defectOperators, derivative squareRootDerivative, t, g mat.Matrix, degree int) (*mat.Dense, error) {
+    m, n := t.Dims()
+
+    if m != n {
+        return nil, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    d := m * (degree + 1)
+
+    if r, c := g.Dims(); r != d || c != d {
+        return nil, fmt.Errorf("Unexpected dimension of gradient: (%d, %d) (Expecting (%d, %d))", r, c, d, d)
+    }
+
+    defect, defectOfTransposed, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    dense := mat.DenseCopyOf(g)
+    block := func(i, j int) mat.Matrix {
+        return dense.Slice(i * m, (i + 1) * m, j * m, (j + 1) * m)
+    }
+
+    w, err := derivative(symmetricPart(defectOfTransposed), block(0, degree))
+
+    if err != nil {
+        return nil, err
+    }
+
+    v, err := derivative(symmetricPart(defect), block(1, 0))
+
+    if err != nil {
+        return nil, err
+    }
+
+    sum := mat.NewDense(m, m, nil)
+    product := mat.NewDense(m, m, nil)
+    gradient := mat.NewDense(m, m, nil)
+    gradient.Sub(block(0, 0), block(1, degree).T())
+
+    sum.Add(w, w.T())
+    product.Mul(sum, t)
+    gradient.Sub(gradient, product)
+
+    sum.Add(v, v.T())
+    product.Mul(t, sum)
+    gradient.Sub(gradient, product)
+
+    return gradient, nil
+}
@@ -2,104 +2,130 @@ package dilation
 
 import (
     "fmt"
-    "github.com/acra5y/go-dilation/internal/eye"
-    "github.com/acra5y/go-dilation/internal/positiveDefinite"
+    "github.com/acra5y/go-dilation/definiteness"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
     "gonum.org/v1/gonum/mat"
 )
 
-type isPositiveDefinite func(positiveDefinite.EigenComputer, *mat.Dense) (bool, error)
+type isPositiveDefinite func(definiteness.EigenComputer, mat.Matrix) (bool, error)
 
-type squareRoot func(*mat.Dense) (*mat.Dense, error)
+type squareRoot func(mat.Matrix) (*mat.Dense, error)
 
-type newBlockMatrixFromSquares func([][]*mat.Dense) (*mat.Dense, error)
+// builds the dilation from its blocks, which are rectangular for a rectangular t
+type newBlockMatrixFromSquares func([][]mat.Matrix) (*mat.Dense, error)
 
+type blockMatrixFromSquaresTo func(*mat.Dense, [][]mat.Matrix) error
+
+// returns the defect operators D_T = sqrt(I - TᵀT) and D_{Tᵀ} = sqrt(I - TTᵀ) of a matrix t or an error, if t is not a contraction
+type defectOperators func(mat.Matrix) (defect, defectOfTransposed *mat.Dense, err error)
+
+// returns I - TTᵀ, which is the squared defect operator of Tᵀ
 func defectOperatorSquared (t mat.Matrix) *mat.Dense {
     n, _ := t.Dims()
-    eye := eye.OfDimension(n)
-
     defectSquared := mat.NewDense(n, n, nil)
 
     defectSquared.Product(t, t.T())
 
-    defectSquared.Sub(eye, defectSquared)
+    defectSquared.Sub(structured.Identity(n), defectSquared)
     return defectSquared
 }
 
-func negativeTranspose(t *mat.Dense) *mat.Dense {
+func negativeTranspose(t mat.Matrix) *mat.Dense {
     m, n := t.Dims()
     data := make([]float64, m * n)
 
-    for i := 0; i < m; i++ {
-        for j := 0; j < n; j++ {
+    for i := 0; i < n; i++ {
+        for j := 0; j < m; j++ {
             data[m * i + j] = (-1) * t.At(j, i)
         }
     }
-    return mat.NewDense(m, n, data)
+    return mat.NewDense(n, m, data)
 }
 
-// See E. Levy und O. M. Shalit: Dilation theory in finite dimensions: the possible, the impossible and the unknown. Rocky Mountain J. Math., 44(1):203-221, 2014
+// reports whether t is known to be symmetric by its type, which does not require to compare any entries
+func isSymmetric(t mat.Matrix) bool {
+    if tr, ok := t.(mat.Transpose); ok {
+        t = tr.Matrix
+    }
 
-func UnitaryNDilation(isPD isPositiveDefinite, sqrt squareRoot, newBlockMatrix newBlockMatrixFromSquares, t *mat.Dense, degree int) (*mat.Dense, error) {
-    m, n := t.Dims()
+    _, ok := t.(mat.Symmetric)
+    return ok
+}
 
-    if m != n {
-        return nil, fmt.Errorf("Matrix does not have square dimension")
+// returns the square root of defectSquared or a *guard.NonFiniteError, if defectSquared or its square root contains NaN or ±Inf
+func checkedSquareRoot(sqrt squareRoot, defectSquared *mat.Dense) (*mat.Dense, error) {
+    if err := guard.CheckFinite(guard.StageDefectSquare, defectSquared); err != nil {
+        return nil, err
     }
 
-    defectSquared := defectOperatorSquared(t)
+    sq, err := sqrt(defectSquared)
 
-    if pd, _ := isPD(&mat.Eigen{}, defectSquared); !pd {
-        return nil, fmt.Errorf("Input is not a contraction")
+    if err != nil {
+        return nil, err
     }
 
-    defectSquaredOfTranspose := defectOperatorSquared(t.T())
-    /*
-        We can calculate the square root as it must exist as we assume T is a positive definite contraction:
-        Let T be a positiv definite complex matrix of dimension n times n with ||T|| < 1 (where ||T|| denotes the operator norm).
-        Let T^* denote the conjugate transpose of T.
-        The equivalency (T^*T)^* = (T^*)(T^*)^* = (T^*)T shows T^*T is hermitian.
-        Let I by the eye Matrix of the same dimension as T.
-        It follows that for a given vector v and the euclidean norm |v|: v^*(I − T^*T)v = v^*Iv − v^*T^*Tv = v^*v − (Tv)^*Tv = |v|^2 − |Tv|^2 > 0.
-        The last step ist based on the requirement that ||T|| < 1.
-        For a positive definite matrix we then know that a square root must exist.
-        See also "Harmonic Analysis of Operators on Hilbert Space" by  B. Sz.-Nagy, chapter I, 1. in section 3.
-        (Please note this hint does not have the ambition to be a mathematical proof on its own).
-    */
-    defect, _ := sqrt(defectSquared)
-    defectOfTransposed, _ := sqrt(defectSquaredOfTranspose)
-
-    rows := make([][]*mat.Dense, degree + 1)
-
-    firstRow := make([]*mat.Dense, degree + 1)
-    secondRow := make([]*mat.Dense, degree + 1)
+    if err := guard.CheckFinite(guard.StageSquareRoot, sq); err != nil {
+        return nil, err
+    }
 
-    blockDim := degree + 1
-    firstRow[0] = t
-    firstRow[blockDim - 1] = defectOfTransposed
-    secondRow[0] = defect
-    secondRow[blockDim - 1] = negativeTranspose(t)
-
-    if degree > 1 {
-        for i := 1; i < blockDim - 1; i++ {
-            firstRow[i] = mat.NewDense(m, n, nil)
-            secondRow[i] = mat.NewDense(m, n, nil)
+    return sq, nil
+}
+
+// Returns defect operators that are calculated by a check for positive definiteness of I - TTᵀ and a square root for each defect operator.
+// Non-finite squared defect operators, which result from an overflow of TTᵀ, and non-finite square roots are reported as *guard.NonFiniteError.
+func SquareRootDefects(isPD isPositiveDefinite, sqrt squareRoot) defectOperators {
+    return func(t mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        defectSquaredOfTransposed := defectOperatorSquared(t)
+
+        // gonum's eigendecomposition does not terminate for non-finite input, so this has to be checked first
+        if err := guard.CheckFinite(guard.StageDefectSquare, defectSquaredOfTransposed); err != nil {
+            return nil, nil, err
+        }
+
+        if pd, _ := isPD(&mat.Eigen{}, defectSquaredOfTransposed); !pd {
+            return nil, nil, fmt.Errorf("Input is not a contraction")
+        }
+
+        /*
+            We can calculate the square root as it must exist as we assume T is a positive definite contraction:
+            Let T be a positiv definite complex matrix of dimension n times n with ||T|| < 1 (where ||T|| denotes the operator norm).
+            Let T^* denote the conjugate transpose of T.
+            The equivalency (T^*T)^* = (T^*)(T^*)^* = (T^*)T shows T^*T is hermitian.
+            Let I by the eye Matrix of the same dimension as T.
+            It follows that for a given vector v and the euclidean norm |v|: v^*(I − T^*T)v = v^*Iv − v^*T^*Tv = v^*v − (Tv)^*Tv = |v|^2 − |Tv|^2 > 0.
+            The last step ist based on the requirement that ||T|| < 1.
+            For a positive definite matrix we then know that a square root must exist.
+            See also "Harmonic Analysis of Operators on Hilbert Space" by  B. Sz.-Nagy, chapter I, 1. in section 3.
+            (Please note this hint does not have the ambition to be a mathematical proof on its own).
+        */
+        defectOfTransposed, err := checkedSquareRoot(sqrt, defectSquaredOfTransposed)
+
+        if err != nil {
+            return nil, nil, err
         }
 
-        for i := 2; i < blockDim; i++ {
-            row := make([]*mat.Dense, blockDim)
-            for j := 0; j < blockDim; j++ {
-                if j == i - 1 {
-                    row[j] = eye.OfDimension(m)
-                } else {
-                    row[j] = mat.NewDense(m, n, nil)
-                }
+        defect := defectOfTransposed
+
+        // For a symmetric T both defect operators coincide, so the second square root can be skipped.
+        if !isSymmetric(t) {
+            if defect, err = checkedSquareRoot(sqrt, defectOperatorSquared(t.T())); err != nil {
+                return nil, nil, err
             }
-            rows[i] = row
         }
+
+        return defect, defectOfTransposed, nil
     }
+}
 
-    rows[0] = firstRow
-    rows[1] = secondRow
+// See E. Levy und O. M. Shalit: Dilation theory in finite dimensions: the possible, the impossible and the unknown. Rocky Mountain J. Math., 44(1):203-221, 2014
+
+func UnitaryNDilation(defects defectOperators, newBlockMatrix newBlockMatrixFromSquares, t mat.Matrix, degree int) (*mat.Dense, error) {
+    rows, err := unitaryNDilationBlocks(defects, t, degree)
+
+    if err != nil {
+        return nil, err
+    }
 
     unitary, err := newBlockMatrix(rows)
 
@@ -109,3 +135,85 @@ func UnitaryNDilation(isPD isPositiveDefinite, sqrt squareRoot, newBlockMatrix n
 
     return unitary, nil
 }
+
+// Same as UnitaryNDilation, but writes the dilation into dst. An empty dst is resized, a non-empty dst must have the dimension of the dilation.
+func UnitaryNDilationTo(defects defectOperators, blockMatrixTo blockMatrixFromSquaresTo, dst *mat.Dense, t mat.Matrix, degree int) error {
+    m, n := t.Dims()
+
+    // Check the destination before doing any work, so a reused buffer with wrong dimension fails fast.
+    if d := m + degree * n; !dst.IsEmpty() {
+        if r, c := dst.Dims(); r != d || c != d {
+            return fmt.Errorf("Unexpected dimension of destination: (%d, %d) (Expecting (%d, %d))", r, c, d, d)
+        }
+    }
+
+    rows, err := unitaryNDilationBlocks(defects, t, degree)
+
+    if err != nil {
+        return err
+    }
+
+    return blockMatrixTo(dst, rows)
+}
+
+// Returns the number of rows of each block row and the number of columns of each block column of the dilation of an m times n matrix
+func Partition(m, n, degree int) (rows, cols []int) {
+    rows = make([]int, degree + 1)
+    cols = make([]int, degree + 1)
+
+    for i := range rows {
+        rows[i] = n
+        cols[i] = n
+    }
+
+    rows[0] = m
+    cols[degree] = m
+    return rows, cols
+}
+
+/*
+    The blocks of the dilation are
+        | T     0 ... 0  D_{Tᵀ} |
+        | D_T   0 ... 0  -Tᵀ    |
+        | 0     I ... 0  0      |
+        | ...                   |
+        | 0     0 ... I  0      |
+    The first two block rows are orthonormal as TTᵀ + D_{Tᵀ}² = I and T D_T = D_{Tᵀ} T.
+    The remaining block rows are the block shift, so their blocks are slices of structured.BlockShift without dense storage.
+    For an m times n matrix t, D_{Tᵀ} has dimension m and D_T dimension n, so the first block row has m rows, the last block column m columns
+    and all other blocks n rows and columns, see Partition. The dilation then has dimension m + degree n and is unitary as well,
+    but only for a square t its compressions are the powers of t.
+*/
+func unitaryNDilationBlocks(defects defectOperators, t mat.Matrix, degree int) ([][]mat.Matrix, error) {
+    m, n := t.Dims()
+    defect, defectOfTransposed, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    blockDim := degree + 1
+    rowSizes, colSizes := Partition(m, n, degree)
+    rows := make([][]mat.Matrix, blockDim)
+    // the block rows 2, ..., degree are the block shift, which never reaches the last block column of m columns
+    shift := structured.BlockShift(blockDim, n)
+
+    for i := range rows {
+        rows[i] = make([]mat.Matrix, blockDim)
+
+        for j := range rows[i] {
+            if i > 1 && j < degree {
+                rows[i][j] = shift.Slice(i * n, (i + 1) * n, j * n, (j + 1) * n)
+            } else {
+                rows[i][j] = structured.NewZero(rowSizes[i], colSizes[j])
+            }
+        }
+    }
+
+    rows[0][0] = t
+    rows[0][blockDim - 1] = defectOfTransposed
+    rows[1][0] = defect
+    rows[1][blockDim - 1] = negativeTranspose(t)
+
+    return rows, nil
+}
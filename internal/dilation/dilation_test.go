@@ -2,15 +2,22 @@ package dilation
 
 import (
     "fmt"
-    "github.com/acra5y/go-dilation/internal/positiveDefinite"
+    "github.com/acra5y/go-dilation/bigmat"
+    "github.com/acra5y/go-dilation/blockmatrix"
+    "github.com/acra5y/go-dilation/definiteness"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/interval"
+    "github.com/acra5y/go-dilation/sqrtm"
     "gonum.org/v1/gonum/mat"
+    "math"
+    "math/big"
     "reflect"
     "testing"
 )
 
 func testIsPositiveDefinite(t *testing.T, expected []*mat.Dense, isPD bool) isPositiveDefinite {
     calls := 0
-    return func(a positiveDefinite.EigenComputer, candidate *mat.Dense) (bool, error) {
+    return func(a definiteness.EigenComputer, candidate mat.Matrix) (bool, error) {
         if !mat.Equal(expected[calls], candidate) {
             t.Errorf("Unexpected argument in call to testIsPositiveDefinite. Got %v: ,want: %v", candidate, expected[calls])
         }
@@ -21,7 +28,7 @@ func testIsPositiveDefinite(t *testing.T, expected []*mat.Dense, isPD bool) isPo
 
 func testSquareRoot(t *testing.T, expected []*mat.Dense) squareRoot {
     calls := 0
-    return func(a *mat.Dense) (*mat.Dense, error) {
+    return func(a mat.Matrix) (*mat.Dense, error) {
         if !mat.Equal(expected[calls], a) {
             t.Errorf("Unexpected argument in call %d to squareRoot. Got: %v, want: %v", calls + 1, a, expected[calls])
         }
@@ -31,7 +38,7 @@ func testSquareRoot(t *testing.T, expected []*mat.Dense) squareRoot {
 }
 
 func testNewBlockMatrixFromSquares(t *testing.T, expected [][]*mat.Dense, errToReturn error) newBlockMatrixFromSquares {
-    return func(rows [][]*mat.Dense) (*mat.Dense, error) {
+    return func(rows [][]mat.Matrix) (*mat.Dense, error) {
         if len(rows) != len(expected) {
             t.Errorf("Unexpected argument in call to newBlockMatrixFromSquares. Wron length of rows, got: %d, want: %d", len(rows), len(expected))
         }
@@ -96,8 +103,10 @@ func TestUnitaryNDilationSuccess(t *testing.T) {
     for _, table := range tables {
         t.Run(table.desc, func(t *testing.T) {
             unitary, err := UnitaryNDilation(
-                testIsPositiveDefinite(t, table.expectedInSqrt, true),
-                testSquareRoot(t, table.expectedInSqrt),
+                SquareRootDefects(
+                    testIsPositiveDefinite(t, table.expectedInSqrt, true),
+                    testSquareRoot(t, table.expectedInSqrt),
+                ),
                 testNewBlockMatrixFromSquares(t, table.expectedRows, nil),
                 table.value,
                 table.degree,
@@ -129,34 +138,132 @@ func TestUnitaryNDilationErrors(t *testing.T) {
         expectedError error
     }{
         {
-            desc: "returns error when matrix is not square",
+            desc: "returns error when matrix not positive definite",
+            value: mat.NewDense(2, 2, nil),
+            isPD: false,
+            blockMatrixErr: nil,
+            expectedError: fmt.Errorf("Input is not a contraction"),
+        },
+        {
+            desc: "returns error when block matrix can not be built",
+            value: mat.NewDense(2, 2, nil),
+            isPD: true,
+            blockMatrixErr: fmt.Errorf("Some Error"),
+            expectedError: fmt.Errorf("Some Error"),
+        },
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            _, err := UnitaryNDilation(
+                SquareRootDefects(
+                    testIsPositiveDefinite(t, expectedIsPDAndSQArgs, table.isPD),
+                    testSquareRoot(t, expectedIsPDAndSQArgs),
+                ),
+                testNewBlockMatrixFromSquares(t, expectedBlockMatrixArgs, table.blockMatrixErr),
+                table.value,
+                1,
+            )
+
+            if !reflect.DeepEqual(err, table.expectedError) {
+                t.Errorf("Unexpected err, want: %v, got: %v", table.expectedError, err)
+            }
+        })
+    }
+}
+
+func testBlockMatrixFromSquaresTo(t *testing.T, expected [][]*mat.Dense, errToReturn error) blockMatrixFromSquaresTo {
+    newBlockMatrix := testNewBlockMatrixFromSquares(t, expected, errToReturn)
+    return func(dst *mat.Dense, rows [][]mat.Matrix) error {
+        _, err := newBlockMatrix(rows)
+        if dst.IsEmpty() {
+            dst.ReuseAs(2, 2)
+        }
+        dst.Set(0, 0, 1)
+        return err
+    }
+}
+
+func TestUnitaryNDilationTo(t *testing.T) {
+    expectedIsPDAndSQArgs := []*mat.Dense{mat.NewDense(2, 2, []float64{1,0,0,1,}),mat.NewDense(2, 2, []float64{1,0,0,1,}),}
+    expectedBlockMatrixArgs := [][]*mat.Dense{
+        []*mat.Dense{mat.NewDense(2, 2, nil),mat.NewDense(2, 2, nil),},
+        []*mat.Dense{mat.NewDense(2, 2, nil),mat.NewDense(2, 2, nil),},
+    }
+    tables := []struct {
+        desc string
+        dst *mat.Dense
+        value *mat.Dense
+        isPD bool
+        blockMatrixErr error
+        expectedError error
+        expectedDst *mat.Dense
+    }{
+        {
+            desc: "writes into an empty destination",
+            dst: &mat.Dense{},
+            value: mat.NewDense(2, 2, nil),
+            isPD: true,
+            blockMatrixErr: nil,
+            expectedError: nil,
+            expectedDst: mat.NewDense(2, 2, []float64{1,0,0,0,}),
+        },
+        {
+            desc: "writes into a destination with matching dimension",
+            dst: mat.NewDense(4, 4, nil),
+            value: mat.NewDense(2, 2, nil),
+            isPD: true,
+            blockMatrixErr: nil,
+            expectedError: nil,
+            expectedDst: mat.NewDense(4, 4, []float64{1,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,}),
+        },
+        {
+            desc: "returns error when destination has wrong dimension",
+            dst: mat.NewDense(4, 3, nil),
+            value: mat.NewDense(2, 2, nil),
+            isPD: true,
+            blockMatrixErr: nil,
+            expectedError: fmt.Errorf("Unexpected dimension of destination: (4, 3) (Expecting (4, 4))"),
+            expectedDst: mat.NewDense(4, 3, nil),
+        },
+        {
+            desc: "returns error when destination does not fit rectangular matrix",
+            dst: mat.NewDense(6, 6, nil),
             value: mat.NewDense(2, 3, nil),
             isPD: true,
             blockMatrixErr: nil,
-            expectedError: fmt.Errorf("Matrix does not have square dimension"),
+            expectedError: fmt.Errorf("Unexpected dimension of destination: (6, 6) (Expecting (5, 5))"),
+            expectedDst: mat.NewDense(6, 6, nil),
         },
         {
             desc: "returns error when matrix not positive definite",
+            dst: mat.NewDense(4, 4, nil),
             value: mat.NewDense(2, 2, nil),
             isPD: false,
             blockMatrixErr: nil,
             expectedError: fmt.Errorf("Input is not a contraction"),
+            expectedDst: mat.NewDense(4, 4, nil),
         },
         {
             desc: "returns error when block matrix can not be built",
+            dst: mat.NewDense(4, 4, nil),
             value: mat.NewDense(2, 2, nil),
             isPD: true,
             blockMatrixErr: fmt.Errorf("Some Error"),
             expectedError: fmt.Errorf("Some Error"),
+            expectedDst: mat.NewDense(4, 4, []float64{1,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,}),
         },
     }
 
     for _, table := range tables {
         t.Run(table.desc, func(t *testing.T) {
-            _, err := UnitaryNDilation(
-                testIsPositiveDefinite(t, expectedIsPDAndSQArgs, table.isPD),
-                testSquareRoot(t, expectedIsPDAndSQArgs),
-                testNewBlockMatrixFromSquares(t, expectedBlockMatrixArgs, table.blockMatrixErr),
+            err := UnitaryNDilationTo(
+                SquareRootDefects(
+                    testIsPositiveDefinite(t, expectedIsPDAndSQArgs, table.isPD),
+                    testSquareRoot(t, expectedIsPDAndSQArgs),
+                ),
+                testBlockMatrixFromSquaresTo(t, expectedBlockMatrixArgs, table.blockMatrixErr),
+                table.dst,
                 table.value,
                 1,
             )
@@ -164,6 +271,625 @@ func TestUnitaryNDilationErrors(t *testing.T) {
             if !reflect.DeepEqual(err, table.expectedError) {
                 t.Errorf("Unexpected err, want: %v, got: %v", table.expectedError, err)
             }
+
+            if !mat.Equal(table.dst, table.expectedDst) {
+                t.Errorf("Wrong destination, want: %v, got: %v", table.expectedDst, table.dst)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationSymmetricInput(t *testing.T) {
+    symmetric := mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,})
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expectedSqrtCalls int
+    }{
+        {desc: "calculates one square root for a symmetric matrix", value: symmetric, expectedSqrtCalls: 1},
+        {desc: "calculates one square root for a transposed symmetric matrix", value: symmetric.T(), expectedSqrtCalls: 1},
+        {desc: "calculates one square root for a diagonal matrix", value: mat.NewDiagDense(2, []float64{0.5,0.2,}), expectedSqrtCalls: 1},
+        {desc: "calculates two square roots for a dense matrix with symmetric entries", value: mat.DenseCopyOf(symmetric), expectedSqrtCalls: 2},
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            calls := 0
+            sqrt := func(a mat.Matrix) (*mat.Dense, error) {
+                calls++
+                return mat.NewDense(2, 2, nil), nil
+            }
+            isPD := func(definiteness.EigenComputer, mat.Matrix) (bool, error) {
+                return true, nil
+            }
+            newBlockMatrix := func([][]mat.Matrix) (*mat.Dense, error) {
+                return mat.NewDense(2, 2, nil), nil
+            }
+
+            _, err := UnitaryNDilation(SquareRootDefects(isPD, sqrt), newBlockMatrix, table.value, 1)
+
+            if err != nil {
+                t.Errorf("Unexpected err, want: %v, got: %v", nil, err)
+            }
+
+            if calls != table.expectedSqrtCalls {
+                t.Errorf("Wrong number of square roots, want: %d, got: %d", table.expectedSqrtCalls, calls)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationPlacesDefects(t *testing.T) {
+    defect := mat.NewDense(2, 2, []float64{1,2,3,4,})
+    defectOfTransposed := mat.NewDense(2, 2, []float64{5,6,7,8,})
+    value := mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,})
+    expectedRows := [][]*mat.Dense{
+        []*mat.Dense{value,defectOfTransposed,},
+        []*mat.Dense{defect,mat.NewDense(2, 2, []float64{-0.5,0,-0.5,-0.5}),},
+    }
+    defects := func(a mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        if !mat.Equal(a, value) {
+            t.Errorf("Unexpected argument in call to defects. Got: %v, want: %v", a, value)
+        }
+        return defect, defectOfTransposed, nil
+    }
+
+    _, err := UnitaryNDilation(defects, testNewBlockMatrixFromSquares(t, expectedRows, nil), value, 1)
+
+    if err != nil {
+        t.Errorf("Unexpected err, want: %v, got: %v", nil, err)
+    }
+}
+
+func TestUnitaryNDilationDefectsError(t *testing.T) {
+    expectedErr := fmt.Errorf("Input is not a contraction")
+    defects := func(a mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        return nil, nil, expectedErr
+    }
+    newBlockMatrix := func([][]mat.Matrix) (*mat.Dense, error) {
+        t.Errorf("Unexpected call to newBlockMatrixFromSquares")
+        return nil, nil
+    }
+
+    unitary, err := UnitaryNDilation(defects, newBlockMatrix, mat.NewDense(2, 2, nil), 1)
+
+    if !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("Unexpected err, want: %v, got: %v", expectedErr, err)
+    }
+
+    if unitary != nil {
+        t.Errorf("Unexpected result, got: %v", unitary)
+    }
+}
+
+func TestSquareRootDefectsSquareRootError(t *testing.T) {
+    expectedErr := fmt.Errorf("Accuracy not reached")
+    tables := []struct {
+        desc string
+        failingCall int
+    }{
+        {desc: "returns error of first square root", failingCall: 1},
+        {desc: "returns error of second square root", failingCall: 2},
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            calls := 0
+            sqrt := func(a mat.Matrix) (*mat.Dense, error) {
+                calls++
+                if calls == table.failingCall {
+                    return nil, expectedErr
+                }
+                return mat.NewDense(2, 2, nil), nil
+            }
+            isPD := func(definiteness.EigenComputer, mat.Matrix) (bool, error) {
+                return true, nil
+            }
+
+            defect, defectOfTransposed, err := SquareRootDefects(isPD, sqrt)(mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}))
+
+            if !reflect.DeepEqual(err, expectedErr) {
+                t.Errorf("Unexpected err, want: %v, got: %v", expectedErr, err)
+            }
+
+            if defect != nil || defectOfTransposed != nil {
+                t.Errorf("Unexpected result, got: %v, %v", defect, defectOfTransposed)
+            }
+        })
+    }
+}
+
+func TestSquareRootDefectsNonFinite(t *testing.T) {
+    isPD := func(definiteness.EigenComputer, mat.Matrix) (bool, error) {
+        return true, nil
+    }
+    sqrtOf := func(value float64) squareRoot {
+        return func(a mat.Matrix) (*mat.Dense, error) {
+            return mat.NewDense(2, 2, []float64{1, 0, 0, value}), nil
+        }
+    }
+
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        sqrt squareRoot
+        expectedErr error
+    }{
+        {
+            desc: "reports an overflow of the squared defect operator",
+            value: mat.NewDense(2, 2, []float64{1e200, 0, 0, 0}),
+            sqrt: sqrtOf(0),
+            expectedErr: &guard.NonFiniteError{Stage: guard.StageDefectSquare, Row: 0, Col: 0, Value: math.Inf(-1)},
+        },
+        {
+            desc: "reports a non-finite square root",
+            value: mat.NewDense(2, 2, []float64{0.5, 0.5, 0, 0.5}),
+            sqrt: sqrtOf(math.Inf(1)),
+            expectedErr: &guard.NonFiniteError{Stage: guard.StageSquareRoot, Row: 1, Col: 1, Value: math.Inf(1)},
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            defect, defectOfTransposed, err := SquareRootDefects(isPD, table.sqrt)(table.value)
+
+            if !reflect.DeepEqual(err, table.expectedErr) {
+                t.Errorf("Unexpected err, want: %v, got: %v", table.expectedErr, err)
+            }
+
+            if defect != nil || defectOfTransposed != nil {
+                t.Errorf("Unexpected result, got: %v, %v", defect, defectOfTransposed)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationBig(t *testing.T) {
+    value := bigmat.NewFromMatrix(mat.NewDense(1, 1, []float64{0.6}), 64)
+    defects := func(a *bigmat.Dense) (*bigmat.Dense, *bigmat.Dense, error) {
+        return bigmat.NewFromMatrix(mat.NewDense(1, 1, []float64{0.8}), 64), bigmat.NewFromMatrix(mat.NewDense(1, 1, []float64{0.7}), 64), nil
+    }
+
+    unitary, err := UnitaryNDilationBig(defects, bigmat.NewBlockMatrixFromSquares, value, 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := mat.NewDense(3, 3, []float64{
+        0.6, 0, 0.7,
+        0.8, 0, -0.6,
+        0, 1, 0,
+    })
+
+    if !mat.Equal(unitary.Float64(), expected) {
+        t.Errorf("Wrong blocks, got: %v, want: %v", mat.Formatted(unitary.Float64()), mat.Formatted(expected))
+    }
+}
+
+func TestBigSquareRootDefects(t *testing.T) {
+    var squares []*mat.Dense
+    sqrt := func(a *bigmat.Dense) (*bigmat.Dense, error) {
+        squares = append(squares, a.Float64())
+        return a, nil
+    }
+    isPD := func(a *bigmat.Dense) (bool, error) {
+        return true, nil
+    }
+
+    value := mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,})
+    defect, defectOfTransposed, err := BigSquareRootDefects(isPD, sqrt)(bigmat.NewFromMatrix(value, 64))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expectedOfTransposed := mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,})
+    expected := mat.NewDense(2, 2, []float64{0.75,-0.25,-0.25,0.5,})
+
+    if !mat.Equal(defectOfTransposed.Float64(), expectedOfTransposed) || !mat.Equal(defect.Float64(), expected) || len(squares) != 2 {
+        t.Errorf("Wrong defects, got: %v, %v", mat.Formatted(defect.Float64()), mat.Formatted(defectOfTransposed.Float64()))
+    }
+
+    notPD := func(a *bigmat.Dense) (bool, error) {
+        return false, nil
+    }
+
+    if _, _, err := BigSquareRootDefects(notPD, sqrt)(bigmat.NewFromMatrix(value, 64)); !reflect.DeepEqual(err, fmt.Errorf("Input is not a contraction")) {
+        t.Errorf("Wrong error, got: %v", err)
+    }
+}
+
+func TestRatSquareRootDefects(t *testing.T) {
+    var squares []*mat.Dense
+    sqrt := func(a *bigmat.Dense) (*bigmat.Dense, error) {
+        squares = append(squares, a.Float64())
+        return a, nil
+    }
+    isPD := func(a *bigmat.RatDense) bool {
+        return true
+    }
+
+    half := big.NewRat(1, 2)
+    value := bigmat.NewRatDense(2, 2, []*big.Rat{half, half, new(big.Rat), half})
+    defect, defectOfTransposed, err := RatSquareRootDefects(isPD, sqrt, 64)(value)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expectedOfTransposed := mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,})
+    expected := mat.NewDense(2, 2, []float64{0.75,-0.25,-0.25,0.5,})
+
+    if !mat.Equal(defectOfTransposed.Float64(), expectedOfTransposed) || !mat.Equal(defect.Float64(), expected) || len(squares) != 2 || defect.Prec() != 64 {
+        t.Errorf("Wrong defects, got: %v, %v", mat.Formatted(defect.Float64()), mat.Formatted(defectOfTransposed.Float64()))
+    }
+
+    notPD := func(a *bigmat.RatDense) bool {
+        return false
+    }
+
+    if _, _, err := RatSquareRootDefects(notPD, sqrt, 64)(value); !reflect.DeepEqual(err, fmt.Errorf("Input is not a contraction")) {
+        t.Errorf("Wrong error, got: %v", err)
+    }
+}
+
+func TestVerifiedSquareRootDefects(t *testing.T) {
+    exact := func(a mat.Matrix) (*mat.Dense, error) {
+        sq, _, err := sqrtm.Sqrt(a, &sqrtm.Settings{Algorithm: sqrtm.Eigen})
+        return sq, err
+    }
+    // the eigenvalues of I - TTᵀ are 0.64, so both defect operators are 0.8 I
+    value := mat.NewDense(2, 2, []float64{0.6,0,0,-0.6,})
+
+    defect, defectOfTransposed, normBound, err := VerifiedSquareRootDefects(exact)(value)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := mat.NewDense(2, 2, []float64{0.8,0,0,0.8,})
+
+    if !defect.Contains(expected) || !defectOfTransposed.Contains(expected) || normBound < 0.6 || normBound > 1 {
+        t.Errorf("Wrong defects, got: %v, %v, %v", defect, defectOfTransposed, normBound)
+    }
+
+    indefinite := func(a mat.Matrix) (*mat.Dense, error) {
+        return mat.NewDense(2, 2, []float64{0.8,0,0,-0.8,}), nil
+    }
+    expectedErr := &guard.VerificationError{Stage: guard.StageSquareRoot, Reason: "could not enclose the square root of I - TTᵀ"}
+
+    if _, _, _, err := VerifiedSquareRootDefects(indefinite)(value); !reflect.DeepEqual(err, expectedErr) {
+        t.Errorf("Wrong error, got: %v, want: %v", err, expectedErr)
+    }
+}
+
+func TestCompressionBounds(t *testing.T) {
+    value := mat.NewDense(1, 1, []float64{0.6})
+    unitary := mat.NewDense(3, 3, []float64{
+        0.6, 0, 0.8,
+        0.8, 0, -0.6,
+        0, 1, 0,
+    })
+
+    if bound := UnitarityBound(unitary); bound > 1e-14 {
+        t.Errorf("Wrong unitarity bound: %e", bound)
+    }
+
+    bounds := CompressionBounds(unitary, value, 2)
+
+    if len(bounds) != 2 || bounds[0] > 1e-15 || bounds[1] > 1e-15 {
+        t.Errorf("Wrong compression bounds: %v", bounds)
+    }
+
+    // a 2-dilation is not a 3-dilation, the compression of the third power differs from 0.6³
+    if bounds := CompressionBounds(unitary, value, 3); bounds[2] < 0.1 {
+        t.Errorf("Compression bound of the third power too small: %v", bounds)
+    }
+}
+
+func TestUnitaryNDilationDerivativeBlocks(t *testing.T) {
+    value := mat.NewDense(1, 1, []float64{0.6})
+    defects := func(a mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        return mat.NewDense(1, 1, []float64{0.8}), mat.NewDense(1, 1, []float64{0.8}), nil
+    }
+    derivative := func(sq mat.Symmetric, e mat.Matrix) (*mat.Dense, error) {
+        return mat.NewDense(1, 1, []float64{e.At(0, 0) / (2 * sq.At(0, 0))}), nil
+    }
+
+    unitary, err := UnitaryNDilationDerivative(defects, derivative, blockmatrix.NewBlockMatrixFromSquares, value, mat.NewDense(1, 1, []float64{1}), 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    // d/dt sqrt(1 - t²) = -t / sqrt(1 - t²) = -0.75
+    expected := mat.NewDense(3, 3, []float64{
+        1, 0, -0.75,
+        -0.75, 0, -1,
+        0, 0, 0,
+    })
+
+    if !mat.EqualApprox(unitary, expected, 1e-15) {
+        t.Errorf("Wrong derivative, got: %v, want: %v", mat.Formatted(unitary), mat.Formatted(expected))
+    }
+
+    gradient, err := UnitaryNDilationAdjoint(defects, derivative, value, mat.NewDense(3, 3, []float64{1, 0, 1, 1, 0, 1, 0, 1, 0}), 2)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    // ⟨G, dU⟩ = 1 - 0.75 - 0.75 - 1 for E = 1
+    if !mat.EqualApprox(gradient, mat.NewDense(1, 1, []float64{-1.5}), 1e-15) {
+        t.Errorf("Wrong gradient, got: %v", mat.Formatted(gradient))
+    }
+}
+
+func TestStructureChecks(t *testing.T) {
+    defects := func(d *mat.Dense) defectOperators {
+        return func(mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+            return d, d, nil
+        }
+    }
+    value := mat.NewSymDense(2, []float64{0.6,0,0,0,})
+
+    tables := []struct {
+        desc string
+        run func() (*mat.Dense, error)
+        expected error
+    }{
+        {
+            desc: "Jordan–Wielandt dilation built asymmetric",
+            run: func() (*mat.Dense, error) {
+                return JordanWielandtDilation(func([][]mat.Matrix) (*mat.Dense, error) {
+                    return mat.NewDense(2, 2, []float64{0,1,0,0,}), nil
+                }, mat.NewDense(1, 1, []float64{1,}))
+            },
+            expected: &guard.VerificationError{Stage: guard.StageStructureCheck, Reason: "dilation is not symmetric at (1, 0)"},
+        },
+        {
+            desc: "Halmos dilation with a wrong defect operator",
+            run: func() (*mat.Dense, error) {
+                return HalmosDilation(defects(mat.NewDense(2, 2, []float64{0.8,0,0,0,})), blockmatrix.NewBlockMatrix, value)
+            },
+            expected: &guard.VerificationError{Stage: guard.StageStructureCheck, Reason: "dilation is not orthogonal, deviation from the identity: 1.414214e+00"},
+        },
+        {
+            desc: "Halmos dilation of an asymmetric matrix",
+            run: func() (*mat.Dense, error) {
+                return HalmosDilation(defects(nil), blockmatrix.NewBlockMatrix, mat.NewDense(2, 2, []float64{0.5,0.1,0,0.5,}))
+            },
+            expected: fmt.Errorf("Matrix is not symmetric"),
+        },
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            u, err := table.run()
+
+            if u != nil || !reflect.DeepEqual(err, table.expected) {
+                t.Errorf("Unexpected result, got: %v, %v, want: nil, %v", u, err, table.expected)
+            }
+        })
+    }
+
+    u, err := HalmosDilation(defects(mat.NewDense(2, 2, []float64{0.8,0,0,1,})), blockmatrix.NewBlockMatrix, value)
+    expected := mat.NewDense(4, 4, []float64{0.6,0,0.8,0, 0,0,0,1, 0.8,0,-0.6,0, 0,1,0,0,})
+
+    if err != nil || !mat.EqualApprox(u, expected, 1e-15) {
+        t.Errorf("Unexpected result, got: %v, %v, want: %v, nil", u, err, expected)
+    }
+}
+
+func TestNormalDefects(t *testing.T) {
+    fallbackCalls := 0
+    fallback := func(mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        fallbackCalls++
+        return nil, nil, fmt.Errorf("Some Error")
+    }
+
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expected *mat.Dense
+        fallbackCalls int
+        err error
+    }{
+        {
+            desc: "scaled rotation",
+            value: mat.NewDense(2, 2, []float64{0,-0.6,0.6,0,}),
+            expected: mat.NewDense(2, 2, []float64{0.8,0,0,0.8,}),
+        },
+        {
+            desc: "symmetric matrix",
+            value: mat.NewSymDense(2, []float64{0.3,0.4,0.4,-0.3,}),
+            expected: mat.NewDense(2, 2, []float64{math.Sqrt(0.75),0,0,math.Sqrt(0.75),}),
+        },
+        {
+            desc: "non normal matrix",
+            value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}),
+            fallbackCalls: 1,
+            err: fmt.Errorf("Some Error"),
+        },
+        {
+            // ‖TᵀT - TTᵀ‖_F ≈ 7e-14 is within the tolerance relative to ‖T‖_F², but the defect operators would differ by up to 3e-10
+            desc: "nearly normal matrix close to norm 1",
+            value: mat.NewDense(2, 2, []float64{1 - 1e-8,1e-13,0,0.5,}),
+            fallbackCalls: 1,
+            err: fmt.Errorf("Some Error"),
+        },
+        {
+            desc: "nearly normal matrix far from norm 1",
+            value: mat.NewDense(2, 2, []float64{0.6,1e-15,0,0,}),
+            expected: mat.NewDense(2, 2, []float64{0.8,0,0,1,}),
+        },
+        {
+            desc: "rectangular matrix",
+            value: mat.NewDense(1, 2, []float64{0.5,0,}),
+            fallbackCalls: 1,
+            err: fmt.Errorf("Some Error"),
+        },
+        {
+            desc: "normal matrix of norm 1",
+            value: mat.NewDense(2, 2, []float64{0,-1,1,0,}),
+            err: fmt.Errorf("Input is not a contraction"),
+        },
+        {
+            desc: "overflow",
+            value: mat.NewDense(1, 1, []float64{1e200,}),
+            err: &guard.NonFiniteError{Stage: guard.StageDefectSquare, Row: 0, Col: 0, Value: math.Inf(1)},
+        },
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            fallbackCalls = 0
+            defect, defectOfTransposed, err := NormalDefects(fallback)(table.value)
+
+            if !reflect.DeepEqual(err, table.err) {
+                t.Errorf("Unexpected err, got: %v, want: %v", err, table.err)
+            }
+
+            if fallbackCalls != table.fallbackCalls {
+                t.Errorf("Unexpected calls of fallback, got: %d, want: %d", fallbackCalls, table.fallbackCalls)
+            }
+
+            if table.expected != nil && (!mat.EqualApprox(defect, table.expected, 1e-15) || defectOfTransposed != defect) {
+                t.Errorf("Unexpected defect operators, got: %v, %v, want: %v", defect, defectOfTransposed, table.expected)
+            }
+        })
+    }
+}
+
+func TestComponents(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expected [][]int
+    }{
+        {desc: "diagonal matrix", value: mat.NewDiagDense(3, []float64{1,0,2,}), expected: [][]int{{0}, {1}, {2}}},
+        {desc: "full matrix", value: mat.NewDense(2, 2, []float64{1,1,1,1,}), expected: [][]int{{0, 1}}},
+        {desc: "permuted blocks", value: mat.NewDense(4, 4, []float64{1,0,0,0, 0,0,0,1, 1,0,0,0, 0,1,0,0,}), expected: [][]int{{0, 2}, {1, 3}}},
+        {desc: "chain through a transposed entry", value: mat.NewDense(3, 3, []float64{0,0,1, 0,0,0, 0,1,0,}), expected: [][]int{{0, 1, 2}}},
+    }
+
+    for _, table := range tables {
+        t.Run(table.desc, func(t *testing.T) {
+            if got := Components(table.value); !reflect.DeepEqual(got, table.expected) {
+                t.Errorf("Unexpected components, got: %v, want: %v", got, table.expected)
+            }
+        })
+    }
+}
+
+func TestKroneckerPermutation(t *testing.T) {
+    // U1 of dimension 4 for m1 = 2 and U2 of dimension 2 for m2 = 1: indices 0, 2 belong to the first blocks
+    expected := []int{0, 2, 1, 3, 4, 5, 6, 7}
+
+    if got, err := KroneckerPermutation(2, 4, 1, 2); err != nil || !reflect.DeepEqual(got.Permutation(), expected) {
+        t.Errorf("Unexpected permutation, got: %v, %v, want: %v", got, err, expected)
+    }
+}
+
+func TestUnitaryNDilationKroneckerVerification(t *testing.T) {
+    value := mat.NewDense(1, 1, []float64{0.6})
+    dilate := func(mat.Matrix) (*mat.Dense, error) {
+        return mat.NewDense(2, 2, []float64{0.6,0.8,0.8,-0.6,}), nil
+    }
+
+    _, _, perm, unitarity, compression, err := UnitaryNDilationKronecker(dilate, value, value, 1)
+
+    if err != nil || perm == nil || !reflect.DeepEqual(perm.Permutation(), []int{0, 1, 2, 3}) || unitarity > 1e-14 || len(compression) != 1 || compression[0] > 1e-15 {
+        t.Errorf("Unexpected result, got: %v, %v, %v, %v", perm, unitarity, compression, err)
+    }
+
+    notUnitary := func(mat.Matrix) (*mat.Dense, error) {
+        return mat.NewDense(2, 2, []float64{0.6,0,0,1,}), nil
+    }
+
+    _, _, _, _, _, err = UnitaryNDilationKronecker(notUnitary, value, value, 1)
+    verificationErr, ok := err.(*guard.VerificationError)
+
+    if !ok || verificationErr.Stage != guard.StageStructureCheck {
+        t.Errorf("Unexpected err, got: %v", err)
+    }
+}
+
+func TestSpecialOrthogonal(t *testing.T) {
+    reflection := mat.NewDense(2, 2, []float64{0.6,0.8,0.8,-0.6,})
+    SpecialOrthogonal(reflection)
+    expected := mat.NewDense(2, 2, []float64{0.6,-0.8,0.8,0.6,})
+
+    if !mat.Equal(reflection, expected) {
+        t.Errorf("Unexpected result, got: %v, want: %v", reflection, expected)
+    }
+
+    SpecialOrthogonal(reflection)
+
+    if !mat.Equal(reflection, expected) {
+        t.Errorf("Rotation changed, got: %v, want: %v", reflection, expected)
+    }
+}
+
+func TestSpecialOrthogonalBig(t *testing.T) {
+    reflection := bigmat.NewFromMatrix(mat.NewDense(2, 2, []float64{0.6,0.8,0.8,-0.6,}), 256)
+    SpecialOrthogonalBig(reflection)
+    expected := mat.NewDense(2, 2, []float64{0.6,-0.8,0.8,0.6,})
+
+    if !mat.Equal(reflection.Float64(), expected) {
+        t.Errorf("Unexpected result, got: %v, want: %v", reflection.Float64(), expected)
+    }
+}
+
+func TestSpecialOrthogonalVerified(t *testing.T) {
+    reflection := interval.NewFromMatrix(mat.NewDense(2, 2, []float64{0.6,0.8,0.8,-0.6,}))
+
+    if err := SpecialOrthogonalVerified(reflection); err != nil || !mat.Equal(reflection.Mid(), mat.NewDense(2, 2, []float64{0.6,-0.8,0.8,0.6,})) {
+        t.Errorf("Unexpected result, got: %v, %v", reflection.Mid(), err)
+    }
+
+    // the enclosed determinants are in [-1e-10, 1e-10], so the midpoints have determinant 0 but matrices of both signs are enclosed
+    ambiguous := interval.NewFromMatrix(mat.NewDense(2, 2, []float64{1,1,1,1,}))
+    ambiguous.Set(1, 1, interval.Interval{Lo: 1 - 1e-10, Hi: 1 + 1e-10})
+    err := SpecialOrthogonalVerified(ambiguous)
+    verificationErr, ok := err.(*guard.VerificationError)
+
+    if !ok || verificationErr.Stage != guard.StageStructureCheck || ambiguous.At(0, 1) != interval.Point(1) {
+        t.Errorf("Unexpected err, got: %v", err)
+    }
+}
+
+func TestForEachComponent(t *testing.T) {
+    for desc, forEach := range map[string]forEachComponent{"sequential": Sequential, "parallel": Parallel} {
+        visited := make([]bool, 4)
+
+        if err := forEach(4, func(k int) error { visited[k] = true; return nil }); err != nil || !reflect.DeepEqual(visited, []bool{true, true, true, true}) {
+            t.Errorf("%s did not visit every component, got: %v, %v", desc, visited, err)
+        }
+
+        err := forEach(4, func(k int) error {
+            if k >= 2 {
+                return fmt.Errorf("Error %d", k)
+            }
+            return nil
         })
+
+        if !reflect.DeepEqual(err, fmt.Errorf("Error 2")) {
+            t.Errorf("%s returned wrong error, got: %v", desc, err)
+        }
+    }
+
+    err := Parallel(2, func(k int) error {
+        if k == 1 {
+            panic("Some Panic")
+        }
+        return nil
+    })
+
+    if !reflect.DeepEqual(err, &guard.PanicError{Value: "Some Panic"}) {
+        t.Errorf("Parallel did not recover the panic, got: %v", err)
     }
 }
@@ -0,0 +1,160 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+// bound of the unitarity and compression residuals of a Kronecker dilation per dimension of t1 ⊗ t2
+const kroneckerTolerance = 1e-8
+
+/*
+    Returns the permutation of the indices of U1 ⊗ U2 that moves the indices r1 N2 + r2 with r1 < m1 and r2 < m2, which span the first block of both
+    dilations, to the front in the order r1 m2 + r2 of t1 ⊗ t2. The remaining indices follow in their original order.
+    N1 and N2 are the dimensions of U1 and U2. Index i of the combined dilation U is index Image(i) of U1 ⊗ U2,
+    so U = Pᵀ (U1 ⊗ U2) P for the returned permutation matrix P.
+*/
+func KroneckerPermutation(m1, n1, m2, n2 int) (*structured.Permutation, error) {
+    perm := make([]int, 0, n1 * n2)
+
+    for r1 := 0; r1 < m1; r1++ {
+        for r2 := 0; r2 < m2; r2++ {
+            perm = append(perm, r1 * n2 + r2)
+        }
+    }
+
+    for r1 := 0; r1 < n1; r1++ {
+        for r2 := 0; r2 < n2; r2++ {
+            if r1 >= m1 || r2 >= m2 {
+                perm = append(perm, r1 * n2 + r2)
+            }
+        }
+    }
+
+    return structured.NewPermutation(perm)
+}
+
+// returns ‖UᵀU - I‖_F and ‖UᵀU‖_F of the dilation u
+func unitarityResidual(u *mat.Dense) (residual, norm float64) {
+    d, _ := u.Dims()
+    gram := mat.NewDense(d, d, nil)
+    gram.Mul(u.T(), u)
+    norm = mat.Norm(gram, 2)
+
+    for i := 0; i < d; i++ {
+        gram.Set(i, i, gram.At(i, i) - 1)
+    }
+
+    return mat.Norm(gram, 2), norm
+}
+
+/*
+    Returns the residuals ‖P U^k Pᵀ - T^k‖_F, the norms ‖P U^k Pᵀ‖_F of the compressions and the norms ‖T^k‖_F for k = 1, ..., degree,
+    where P Pᵀ projects onto the first block of the dilation u of the square matrix t.
+*/
+func compressionResiduals(u *mat.Dense, t mat.Matrix, degree int) (residuals, compressions, powers []float64) {
+    m, _ := t.Dims()
+    power := mat.DenseCopyOf(u)
+    expected := mat.DenseCopyOf(t)
+    residuals = make([]float64, degree)
+    compressions = make([]float64, degree)
+    powers = make([]float64, degree)
+
+    for k := 0; k < degree; k++ {
+        compression := power.Slice(0, m, 0, m)
+        difference := mat.NewDense(m, m, nil)
+        difference.Sub(compression, expected)
+
+        residuals[k] = mat.Norm(difference, 2)
+        compressions[k] = mat.Norm(compression, 2)
+        powers[k] = mat.Norm(expected, 2)
+
+        next := mat.NewDense(power.RawMatrix().Rows, power.RawMatrix().Cols, nil)
+        next.Mul(power, u)
+        power = next
+
+        nextExpected := mat.NewDense(m, m, nil)
+        nextExpected.Mul(expected, t)
+        expected = nextExpected
+    }
+
+    return residuals, compressions, powers
+}
+
+/*
+    Returns bounds of ‖UᵀU - I‖_F and of ‖P U^k Pᵀ - (T1 ⊗ T2)^k‖_F for k = 1, ..., degree of the combined dilation U of u1 and u2,
+    calculated from the factors only: as U is a permutation of U1 ⊗ U2 and the compressions of U^k are P1 U1^k P1ᵀ ⊗ P2 U2^k P2ᵀ,
+    both are bounded by ‖A ⊗ B - C ⊗ D‖_F ≤ ‖A - C‖_F ‖B‖_F + ‖C‖_F ‖B - D‖_F, which does not cancel like the exact expansion of the norm.
+*/
+func KroneckerBounds(u1 *mat.Dense, t1 mat.Matrix, u2 *mat.Dense, t2 mat.Matrix, degree int) (unitarity float64, compression []float64) {
+    d1, _ := u1.Dims()
+    residual1, _ := unitarityResidual(u1)
+    residual2, gram2 := unitarityResidual(u2)
+
+    // ‖I‖_F = sqrt(d1)
+    unitarity = residual1 * gram2 + math.Sqrt(float64(d1)) * residual2
+
+    residuals1, _, powers1 := compressionResiduals(u1, t1, degree)
+    residuals2, compressions2, _ := compressionResiduals(u2, t2, degree)
+    compression = make([]float64, degree)
+
+    for k := range compression {
+        compression[k] = residuals1[k] * compressions2[k] + powers1[k] * residuals2[k]
+    }
+
+    return unitarity, compression
+}
+
+/*
+    Returns the degree-dilations u1 and u2 of the square matrices t1 and t2 calculated by dilate, the permutation that combines them into a dilation
+    of t1 ⊗ t2, see KroneckerPermutation, and the bounds of KroneckerBounds. I - (T1 ⊗ T2)(T1 ⊗ T2)ᵀ and the combined dilation are never formed,
+    only the dilations of the factors. The combined dilation has dimension m1 m2 (degree + 1)² and is checked to be unitary and to compress
+    to the powers of t1 ⊗ t2 up to kroneckerTolerance per dimension of t1 ⊗ t2, a *guard.VerificationError is returned otherwise.
+*/
+func UnitaryNDilationKronecker(dilate func(mat.Matrix) (*mat.Dense, error), t1, t2 mat.Matrix, degree int) (u1, u2 *mat.Dense, perm *structured.Permutation, unitarity float64, compression []float64, err error) {
+    for _, t := range []mat.Matrix{t1, t2} {
+        if m, n := t.Dims(); m != n {
+            return nil, nil, nil, 0, nil, fmt.Errorf("Matrix does not have square dimension")
+        }
+    }
+
+    if u1, err = dilate(t1); err != nil {
+        return nil, nil, nil, 0, nil, err
+    }
+
+    if u2, err = dilate(t2); err != nil {
+        return nil, nil, nil, 0, nil, err
+    }
+
+    m1, _ := t1.Dims()
+    m2, _ := t2.Dims()
+    n1, _ := u1.Dims()
+    n2, _ := u2.Dims()
+    unitarity, compression = KroneckerBounds(u1, t1, u2, t2, degree)
+    tolerance := kroneckerTolerance * float64(m1 * m2)
+
+    if !(unitarity <= tolerance) {
+        return nil, nil, nil, 0, nil, &guard.VerificationError{
+            Stage: guard.StageStructureCheck,
+            Reason: fmt.Sprintf("dilation of the tensor product is not unitary, bound of the deviation from the identity: %e", unitarity),
+        }
+    }
+
+    for k, bound := range compression {
+        if !(bound <= tolerance) {
+            return nil, nil, nil, 0, nil, &guard.VerificationError{
+                Stage: guard.StageStructureCheck,
+                Reason: fmt.Sprintf("compression of power %d of the dilation of the tensor product is not the power of the tensor product, bound of the residual: %e", k + 1, bound),
+            }
+        }
+    }
+
+    if perm, err = KroneckerPermutation(m1, n1, m2, n2); err != nil {
+        return nil, nil, nil, 0, nil, err
+    }
+
+    return u1, u2, perm, unitarity, compression, nil
+}
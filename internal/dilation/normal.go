@@ -0,0 +1,93 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+// bound of the error of the defect operators, below which t is treated as normal, see NormalDefects
+const normalTolerance = 1e-12
+
+/*
+    Returns ‖TᵀT - TTᵀ‖_F of the square matrix t and true, if it is at most normalTolerance relative to ‖T‖_F², or false otherwise.
+    A symmetric type is exactly normal without comparing any entries.
+*/
+func normalCommutator(t mat.Matrix, gram *mat.Dense) (float64, bool) {
+    if m, n := t.Dims(); m != n {
+        return 0, false
+    }
+
+    if isSymmetric(t) {
+        return 0, true
+    }
+
+    var commutator mat.Dense
+    commutator.Mul(t, t.T())
+    commutator.Sub(gram, &commutator)
+    norm := mat.Norm(&commutator, 2)
+
+    return norm, norm <= normalTolerance * math.Pow(mat.Norm(t, 2), 2)
+}
+
+/*
+    Returns defect operators that are calculated eigenvalue by eigenvalue for a normal t and by fallback otherwise.
+    A real normal T is orthogonally similar to a block diagonal matrix Λ of its real eigenvalues and of 2 times 2 rotation blocks [[a, b], [-b, a]]
+    of its complex eigenvalues a ± ib, so TᵀT = TTᵀ = V diag(|λ_i|²) Vᵀ and both defect operators are V diag(sqrt(1 - |λ_i|²)) Vᵀ.
+    The dilation then is the dilation of Λ conjugated by V, which consists of a small rotation block [[λ, d], [d, -λ̄]] with d = sqrt(1 - |λ|²)
+    for each eigenvalue. One eigendecomposition of the symmetric TᵀT replaces the positive definite check and the square roots of fallback.
+    For a t that is normal only up to rounding, sqrt(I - TᵀT) is used for D_{Tᵀ} as well. Both square roots are at least sqrt(1 - ‖T‖₂²) I,
+    so they differ by at most ‖TᵀT - TTᵀ‖_F / (2 sqrt(1 - ‖T‖₂²)), which grows as ‖T‖₂ → 1. If this bound exceeds normalTolerance,
+    the defect operators are calculated by fallback instead, so only exactly normal matrices take this path close to the boundary.
+*/
+func NormalDefects(fallback defectOperators) defectOperators {
+    return func(t mat.Matrix) (*mat.Dense, *mat.Dense, error) {
+        _, n := t.Dims()
+        gram := mat.NewDense(n, n, nil)
+        gram.Mul(t.T(), t)
+
+        // gonum's eigendecomposition does not terminate for non-finite input, so this has to be checked first
+        if err := guard.CheckFinite(guard.StageDefectSquare, gram); err != nil {
+            return nil, nil, err
+        }
+
+        commutator, ok := normalCommutator(t, gram)
+
+        if !ok {
+            return fallback(t)
+        }
+
+        var eigen mat.EigenSym
+
+        if ok := eigen.Factorize(mat.NewSymDense(n, gram.RawMatrix().Data), true); !ok {
+            return fallback(t)
+        }
+
+        values := eigen.Values(nil)
+
+        // the eigenvalues are in ascending order
+        if !(values[n - 1] < 1) {
+            return nil, nil, fmt.Errorf("Input is not a contraction")
+        }
+
+        if commutator > 0 && !(commutator / (2 * math.Sqrt(1 - values[n - 1])) <= normalTolerance) {
+            return fallback(t)
+        }
+
+        var vectors mat.Dense
+        eigen.VectorsTo(&vectors)
+
+        scaled := mat.DenseCopyOf(&vectors)
+        scaled.Apply(func(i, j int, v float64) float64 {
+            return v * math.Sqrt(1 - math.Max(values[j], 0))
+        }, scaled)
+
+        product := mat.NewDense(n, n, nil)
+        product.Mul(scaled, vectors.T())
+
+        // V diag(d) Vᵀ is symmetric up to rounding
+        defect := symmetrized(product)
+        return defect, defect, nil
+    }
+}
@@ -0,0 +1,76 @@
+package dilation
+
+import (
+    "github.com/acra5y/go-dilation/bigmat"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/interval"
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+)
+
+// reports whether the orthogonal matrix u has determinant -1
+func ReversesOrientation(u mat.Matrix) bool {
+    _, sign := mat.LogDet(u)
+    return sign < 0
+}
+
+/*
+    Negates the last column of the orthogonal dilation u, if its determinant is -1, so that u is special orthogonal with determinant +1.
+    The last column belongs to the last block column [D_{Tᵀ}, -Tᵀ, 0, ..., 0]ᵀ, which only acts on the last block: a vector of the first block
+    reaches the second block after one step and the last block after degree steps of the block shift, so it needs degree + 1 steps to return
+    through the last block column to the first block. The compressions P U^k Pᵀ = T^k for k = 1, ..., degree therefore do not change,
+    and u stays orthogonal, as the reflection of a single coordinate is orthogonal.
+*/
+func SpecialOrthogonal(u *mat.Dense) {
+    if ReversesOrientation(u) {
+        NegateLastColumn(u)
+    }
+}
+
+// negates the last column of u, which is the reflection of SpecialOrthogonal applied to u or to the gradient of a loss of u
+func NegateLastColumn(u *mat.Dense) {
+    r, c := u.Dims()
+
+    for i := 0; i < r; i++ {
+        u.Set(i, c - 1, -u.At(i, c - 1))
+    }
+}
+
+// Same as SpecialOrthogonal in the precision of u, the sign of the determinant is calculated by bigmat.DeterminantSign
+func SpecialOrthogonalBig(u *bigmat.Dense) {
+    if bigmat.DeterminantSign(u) >= 0 {
+        return
+    }
+
+    r, c := u.Dims()
+
+    for i := 0; i < r; i++ {
+        u.Set(i, c - 1, new(big.Float).Neg(u.At(i, c - 1)))
+    }
+}
+
+/*
+    Same as SpecialOrthogonal for an enclosure of the dilation. The sign of the determinant is proven for every matrix of the enclosure
+    by interval.DeterminantSign, a *guard.VerificationError is returned if it could not be proven. Negating an interval is exact,
+    so the result encloses the negated exact dilation.
+*/
+func SpecialOrthogonalVerified(u *interval.Dense) error {
+    sign, ok := interval.DeterminantSign(u)
+
+    if !ok {
+        return &guard.VerificationError{Stage: guard.StageStructureCheck, Reason: "could not prove the sign of the determinant of the dilation"}
+    }
+
+    if sign > 0 {
+        return nil
+    }
+
+    r, c := u.Dims()
+
+    for i := 0; i < r; i++ {
+        v := u.At(i, c - 1)
+        u.Set(i, c - 1, interval.Interval{Lo: -v.Hi, Hi: -v.Lo})
+    }
+
+    return nil
+}
@@ -0,0 +1,62 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/bigmat"
+)
+
+type ratIsPositiveDefinite func(*bigmat.RatDense) bool
+
+// returns the defect operators of a rational t in the given precision
+type ratDefectOperators func(*bigmat.RatDense) (defect, defectOfTransposed *bigmat.Dense, err error)
+
+// returns I - TTᵀ exactly
+func ratDefectOperatorSquared(t *bigmat.RatDense) *bigmat.RatDense {
+    n, _ := t.Dims()
+    return bigmat.RatSub(bigmat.RatIdentity(n), bigmat.RatMul(t, t.T()))
+}
+
+/*
+    Returns defect operators of a rational t, for which I - TTᵀ and I - TᵀT are calculated exactly,
+    so the decision whether t is a contraction is exact. Only the square roots are calculated in the precision prec.
+*/
+func RatSquareRootDefects(isPD ratIsPositiveDefinite, sqrt bigSquareRoot, prec uint) ratDefectOperators {
+    return func(t *bigmat.RatDense) (*bigmat.Dense, *bigmat.Dense, error) {
+        defectSquaredOfTransposed := ratDefectOperatorSquared(t)
+
+        if !isPD(defectSquaredOfTransposed) {
+            return nil, nil, fmt.Errorf("Input is not a contraction")
+        }
+
+        defectOfTransposed, err := sqrt(defectSquaredOfTransposed.Dense(prec))
+
+        if err != nil {
+            return nil, nil, err
+        }
+
+        defect, err := sqrt(ratDefectOperatorSquared(t.T()).Dense(prec))
+
+        if err != nil {
+            return nil, nil, err
+        }
+
+        return defect, defectOfTransposed, nil
+    }
+}
+
+// Same as UnitaryNDilationBig for a rational t, whose entries are rounded to the precision prec in the dilation.
+func UnitaryNDilationRat(defects ratDefectOperators, newBlockMatrix newBigBlockMatrixFromSquares, t *bigmat.RatDense, degree int, prec uint) (*bigmat.Dense, error) {
+    m, n := t.Dims()
+
+    if m != n {
+        return nil, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    defect, defectOfTransposed, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    return newBlockMatrix(bigUnitaryNDilationBlocks(t.Dense(prec), defect, defectOfTransposed, degree))
+}
@@ -0,0 +1,135 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "gonum.org/v1/gonum/mat"
+)
+
+// bound of ‖UᵀU - I‖_F and ‖U² - I‖_F per dimension of U, which the rounding of the defect operators has to stay below
+const structureTolerance = 1e-10
+
+// returns a *guard.VerificationError, if u is not exactly symmetric
+func checkSymmetric(u mat.Matrix) error {
+    n, _ := u.Dims()
+
+    for i := 0; i < n; i++ {
+        for j := 0; j < i; j++ {
+            if u.At(i, j) != u.At(j, i) {
+                return &guard.VerificationError{
+                    Stage: guard.StageStructureCheck,
+                    Reason: fmt.Sprintf("dilation is not symmetric at (%d, %d)", i, j),
+                }
+            }
+        }
+    }
+
+    return nil
+}
+
+// returns a *guard.VerificationError, if ‖ab - I‖_F exceeds the structureTolerance, the property names what ab = I means for the dilation
+func checkIdentity(property string, a, b mat.Matrix) error {
+    n, _ := a.Dims()
+    product := mat.NewDense(n, n, nil)
+    product.Mul(a, b)
+
+    for i := 0; i < n; i++ {
+        product.Set(i, i, product.At(i, i) - 1)
+    }
+
+    if deviation := mat.Norm(product, 2); !(deviation <= structureTolerance * float64(n)) {
+        return &guard.VerificationError{
+            Stage: guard.StageStructureCheck,
+            Reason: fmt.Sprintf("dilation is not %s, deviation from the identity: %e", property, deviation),
+        }
+    }
+
+    return nil
+}
+
+// returns (a + aᵀ) / 2, so rounding in a square root does not break the symmetry of a dilation
+func symmetrized(a *mat.Dense) *mat.Dense {
+    n, _ := a.Dims()
+    s := mat.NewDense(n, n, nil)
+    s.Add(a, a.T())
+    s.Scale(0.5, s)
+    return s
+}
+
+/*
+    Returns the Jordan–Wielandt dilation
+        | 0   T |
+        | Tᵀ  0 |
+    of an m times n matrix t, which is symmetric of dimension m + n with the eigenvalues ±σ_i of the singular values of t and m + n - 2 min(m, n) zeros.
+    The dilation is not restricted to contractions.
+*/
+func JordanWielandtDilation(newBlockMatrix newBlockMatrixFromSquares, t mat.Matrix) (*mat.Dense, error) {
+    m, n := t.Dims()
+
+    u, err := newBlockMatrix([][]mat.Matrix{
+        []mat.Matrix{structured.NewZero(m, m), t},
+        []mat.Matrix{t.T(), structured.NewZero(n, n)},
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    if err := checkSymmetric(u); err != nil {
+        return nil, err
+    }
+
+    return u, nil
+}
+
+/*
+    Returns the Halmos dilation
+        | T  D  |
+        | D  -T |
+    of a symmetric contraction t with D = sqrt(I - T²), which is symmetric and orthogonal and so an involution, as T and D commute.
+    The dilation is checked to be symmetric, orthogonal and involutive before it is returned.
+*/
+func HalmosDilation(defects defectOperators, newBlockMatrix newBlockMatrixFromSquares, t mat.Matrix) (*mat.Dense, error) {
+    if m, n := t.Dims(); m != n {
+        return nil, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    if !mat.Equal(t, t.T()) {
+        return nil, fmt.Errorf("Matrix is not symmetric")
+    }
+
+    // for a symmetric t both defect operators are sqrt(I - TTᵀ) = sqrt(I - T²)
+    _, defect, err := defects(t)
+
+    if err != nil {
+        return nil, err
+    }
+
+    defect = symmetrized(defect)
+    negative := mat.DenseCopyOf(t)
+    negative.Scale(-1, negative)
+
+    u, err := newBlockMatrix([][]mat.Matrix{
+        []mat.Matrix{t, defect},
+        []mat.Matrix{defect, negative},
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    if err := checkSymmetric(u); err != nil {
+        return nil, err
+    }
+
+    if err := checkIdentity("orthogonal", u.T(), u); err != nil {
+        return nil, err
+    }
+
+    if err := checkIdentity("involutive", u, u); err != nil {
+        return nil, err
+    }
+
+    return u, nil
+}
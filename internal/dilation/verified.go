@@ -0,0 +1,149 @@
+package dilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/interval"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+// returns enclosures of the defect operators of a square matrix t and a rigorous upper bound of ‖T‖₂
+type verifiedDefectOperators func(mat.Matrix) (defect, defectOfTransposed *interval.Dense, normBound float64, err error)
+
+// returns an enclosure of I - TTᵀ
+func intervalDefectOperatorSquared(t *interval.Dense) *interval.Dense {
+    n, _ := t.Dims()
+    return interval.Sub(interval.Identity(n), interval.Mul(t, t.T()))
+}
+
+// returns an enclosure of the square root of the enclosed squared defect operator, which is approximated by sqrt
+func verifiedSquareRoot(sqrt squareRoot, defectSquared *interval.Dense, name string) (*interval.Dense, error) {
+    approx, err := checkedSquareRoot(sqrt, defectSquared.Mid())
+
+    if err != nil {
+        return nil, err
+    }
+
+    enclosure, ok := interval.SqrtEnclosure(defectSquared, approx)
+
+    if !ok {
+        return nil, &guard.VerificationError{Stage: guard.StageSquareRoot, Reason: fmt.Sprintf("could not enclose the square root of %s", name)}
+    }
+
+    return enclosure, nil
+}
+
+/*
+    Returns defect operators that are enclosed in interval arithmetic. T is proven to be a contraction by an interval Cholesky decomposition of I - TTᵀ,
+    t is proven not to be a contraction by vᵀ(I - TTᵀ)v ≤ 0 for an approximate eigenvector v, otherwise a *guard.VerificationError is returned.
+    The square roots approximated by sqrt are enclosed by interval.SqrtEnclosure.
+*/
+func VerifiedSquareRootDefects(sqrt squareRoot) verifiedDefectOperators {
+    return func(t mat.Matrix) (*interval.Dense, *interval.Dense, float64, error) {
+        ti := interval.NewFromMatrix(t)
+        defectSquaredOfTransposed := intervalDefectOperatorSquared(ti)
+        mu, ok := interval.LowerEigenvalueBound(defectSquaredOfTransposed)
+
+        if !ok {
+            if interval.IsNotPositiveDefinite(defectSquaredOfTransposed) {
+                return nil, nil, 0, fmt.Errorf("Input is not a contraction")
+            }
+
+            return nil, nil, 0, &guard.VerificationError{Stage: guard.StagePositiveDefiniteCheck, Reason: "could not prove that I - TTᵀ is positive definite"}
+        }
+
+        // ‖T‖₂² = 1 - λ_min(I - TTᵀ) ≤ 1 - μ, and ‖T‖₂ < 1 is proven even if μ is 0
+        normBound := math.Min(interval.Point(1).Sub(interval.Point(mu)).Sqrt().Hi, 1)
+
+        defectOfTransposed, err := verifiedSquareRoot(sqrt, defectSquaredOfTransposed, "I - TTᵀ")
+
+        if err != nil {
+            return nil, nil, 0, err
+        }
+
+        defect, err := verifiedSquareRoot(sqrt, intervalDefectOperatorSquared(ti.T()), "I - TᵀT")
+
+        if err != nil {
+            return nil, nil, 0, err
+        }
+
+        return defect, defectOfTransposed, normBound, nil
+    }
+}
+
+// Same as UnitaryNDilation, but returns enclosures of the entries of the dilation with the blocks laid out as described for unitaryNDilationBlocks.
+func UnitaryNDilationVerified(defects verifiedDefectOperators, t mat.Matrix, degree int) (unitary *interval.Dense, normBound float64, err error) {
+    m, n := t.Dims()
+
+    if m != n {
+        return nil, 0, fmt.Errorf("Matrix does not have square dimension")
+    }
+
+    defect, defectOfTransposed, normBound, err := defects(t)
+
+    if err != nil {
+        return nil, 0, err
+    }
+
+    ti := interval.NewFromMatrix(t)
+    blockDim := degree + 1
+    rows := make([][]*interval.Dense, blockDim)
+    zero := interval.NewDense(m, n)
+    identity := interval.Identity(m)
+
+    for i := range rows {
+        rows[i] = make([]*interval.Dense, blockDim)
+
+        for j := range rows[i] {
+            if i > 1 && j == i - 1 {
+                rows[i][j] = identity
+            } else {
+                rows[i][j] = zero
+            }
+        }
+    }
+
+    rows[0][0] = ti
+    rows[0][blockDim - 1] = defectOfTransposed
+    rows[1][0] = defect
+    rows[1][blockDim - 1] = interval.Scale(interval.Point(-1), ti.T())
+
+    unitary, err = interval.NewBlockMatrixFromSquares(rows)
+
+    if err != nil {
+        return nil, 0, err
+    }
+
+    return unitary, normBound, nil
+}
+
+// returns a rigorous upper bound of ‖UᵀU - I‖_F
+func UnitarityBound(u mat.Matrix) float64 {
+    ui := interval.NewFromMatrix(u)
+    n, _ := u.Dims()
+    return interval.Sub(interval.Mul(ui.T(), ui), interval.Identity(n)).NormBound()
+}
+
+// returns rigorous upper bounds of ‖P U^k Pᵀ - T^k‖_F for k = 1, ..., degree, where P Pᵀ projects onto the first block of U of the dimension of t
+func CompressionBounds(u, t mat.Matrix, degree int) []float64 {
+    m, _ := t.Dims()
+    n, _ := u.Dims()
+    ui := interval.NewFromMatrix(u)
+    ti := interval.NewFromMatrix(t)
+    // the first block column of U^k and T^k
+    power := ui.Slice(0, n, 0, m)
+    powerOfT := ti
+    bounds := make([]float64, degree)
+
+    for k := 1; k <= degree; k++ {
+        if k > 1 {
+            power = interval.Mul(ui, power)
+            powerOfT = interval.Mul(ti, powerOfT)
+        }
+
+        bounds[k - 1] = interval.Sub(power.Slice(0, m, 0, m), powerOfT).NormBound()
+    }
+
+    return bounds
+}
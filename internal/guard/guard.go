@@ -0,0 +1,142 @@
+/*
+    Package guard validates matrices at the boundary of the public packages and converts panics into errors,
+    so that no input makes the library panic.
+*/
+package guard
+
+import (
+    "errors"
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "reflect"
+)
+
+// returned for a nil matrix, including a typed nil pointer such as (*mat.Dense)(nil)
+var ErrNilMatrix = errors.New("Matrix is nil")
+
+// returned for a matrix without rows or columns, such as the zero value of mat.Dense
+var ErrEmptyMatrix = errors.New("Matrix is empty")
+
+// returned instead of a panic, e.g. one of gonum's mat.Error panics for mismatching dimensions.
+// If the panic value is an error, it is returned by Unwrap, so errors.Is(err, mat.ErrShape) works as expected
+type PanicError struct {
+    Value interface{}
+}
+
+func (e *PanicError) Error() string {
+    return fmt.Sprintf("Unexpected panic: %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+    err, _ := e.Value.(error)
+    return err
+}
+
+// must be deferred directly, it replaces *err with a *PanicError if the deferring function panics
+func Recover(err *error) {
+    if r := recover(); r != nil {
+        *err = &PanicError{Value: r}
+    }
+}
+
+// returns ErrNilMatrix or ErrEmptyMatrix for a matrix that is nil or has no entries
+func CheckMatrix(m mat.Matrix) error {
+    if m == nil {
+        return ErrNilMatrix
+    }
+
+    if v := reflect.ValueOf(m); v.Kind() == reflect.Ptr && v.IsNil() {
+        return ErrNilMatrix
+    }
+
+    if r, c := m.Dims(); r == 0 || c == 0 {
+        return ErrEmptyMatrix
+    }
+
+    return nil
+}
+
+// Stage of a calculation, reported with a non-finite value it produced and to observers
+type Stage int
+
+const (
+    // the input of the calculation
+    StageInput Stage = iota
+    // the squared defect operator I - TTᵀ or I - TᵀT
+    StageDefectSquare
+    // the square root of a squared defect operator
+    StageSquareRoot
+    // the linear solve Q = S_{i+1}S_i^{-1} - I of the Exponential Method
+    StageSolve
+    // the singular value decomposition of the contraction
+    StageSingularValueDecomposition
+    // the check whether I - TTᵀ is positive definite
+    StagePositiveDefiniteCheck
+    // the calculation of both defect operators
+    StageDefects
+    // the assembly of the dilation from its blocks
+    StageBlockAssembly
+    // the check of the structure a dilation promises, e.g. that it is symmetric or orthogonal
+    StageStructureCheck
+)
+
+func (s Stage) String() string {
+    switch s {
+    case StageInput:
+        return "input"
+    case StageDefectSquare:
+        return "squared defect operator"
+    case StageSquareRoot:
+        return "square root"
+    case StageSolve:
+        return "linear solve"
+    case StageSingularValueDecomposition:
+        return "singular value decomposition"
+    case StagePositiveDefiniteCheck:
+        return "positive definite check"
+    case StageDefects:
+        return "defect operators"
+    case StageBlockAssembly:
+        return "block assembly"
+    case StageStructureCheck:
+        return "structure check"
+    }
+    return fmt.Sprintf("Stage(%d)", int(s))
+}
+
+// returned if a matrix contains NaN or ±Inf, Row and Col locate the first such entry
+type NonFiniteError struct {
+    Stage Stage
+    Row, Col int
+    Value float64
+}
+
+func (e *NonFiniteError) Error() string {
+    return fmt.Sprintf("Non-finite value %v at (%d, %d) in %v", e.Value, e.Row, e.Col, e.Stage)
+}
+
+// returns a *NonFiniteError for the first entry of m that is NaN or ±Inf, reported for the given stage
+func CheckFinite(stage Stage, m mat.Matrix) error {
+    r, c := m.Dims()
+
+    for i := 0; i < r; i++ {
+        for j := 0; j < c; j++ {
+            if v := m.At(i, j); math.IsNaN(v) || math.IsInf(v, 0) {
+                return &NonFiniteError{Stage: stage, Row: i, Col: j, Value: v}
+            }
+        }
+    }
+
+    return nil
+}
+
+// returned if a verified calculation could not prove its result, which does not mean that the result is wrong
+type VerificationError struct {
+    Stage Stage
+    Reason string
+}
+
+func (e *VerificationError) Error() string {
+    return fmt.Sprintf("Verification failed in %v: %s", e.Stage, e.Reason)
+}
@@ -0,0 +1,105 @@
+package guard
+
+import (
+    "errors"
+    "math"
+    "gonum.org/v1/gonum/mat"
+    "testing"
+)
+
+func TestCheckMatrix(t *testing.T) {
+    var nilDense *mat.Dense
+
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expected error
+    }{
+        {desc: "nil", value: nil, expected: ErrNilMatrix},
+        {desc: "typed nil", value: nilDense, expected: ErrNilMatrix},
+        {desc: "empty", value: &mat.Dense{}, expected: ErrEmptyMatrix},
+        {desc: "valid", value: mat.NewDense(1, 2, nil), expected: nil},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            if err := CheckMatrix(table.value); err != table.expected {
+                t.Errorf("CheckMatrix returned wrong error, got: %v, want: %v", err, table.expected)
+            }
+        })
+    }
+}
+
+func mismatchingProduct() (err error) {
+    defer Recover(&err)
+
+    var product mat.Dense
+    product.Mul(mat.NewDense(1, 2, nil), mat.NewDense(1, 2, nil))
+    return nil
+}
+
+func TestRecover(t *testing.T) {
+    err := mismatchingProduct()
+
+    var panicErr *PanicError
+
+    if !errors.As(err, &panicErr) || panicErr.Value != mat.ErrShape {
+        t.Errorf("Recover returned wrong error, got: %v", err)
+    }
+
+    if !errors.Is(err, mat.ErrShape) {
+        t.Errorf("PanicError does not unwrap to the panic value, got: %v", errors.Unwrap(err))
+    }
+}
+
+func TestCheckFinite(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expected error
+    }{
+        {desc: "finite", value: mat.NewDense(2, 2, []float64{1, -1e308, 0, 5e-324}), expected: nil},
+        {desc: "NaN", value: mat.NewDense(2, 2, []float64{1, 0, math.NaN(), math.Inf(1)}), expected: &NonFiniteError{Stage: StageSolve, Row: 1, Col: 0, Value: math.NaN()}},
+        {desc: "Inf", value: mat.NewDense(2, 2, []float64{1, math.Inf(-1), 0, 0}), expected: &NonFiniteError{Stage: StageSolve, Row: 0, Col: 1, Value: math.Inf(-1)}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            err := CheckFinite(StageSolve, table.value)
+
+            if table.expected == nil {
+                if err != nil {
+                    t.Errorf("CheckFinite returned unexpected error: %v", err)
+                }
+                return
+            }
+
+            // NaN does not equal itself, so the errors are compared by their message
+            if err == nil || err.Error() != table.expected.Error() {
+                t.Errorf("CheckFinite returned wrong error, got: %v, want: %v", err, table.expected)
+            }
+        })
+    }
+}
+
+func TestStageString(t *testing.T) {
+    if s := StageDefectSquare.String(); s != "squared defect operator" {
+        t.Errorf("Wrong string, got: %s", s)
+    }
+
+    if s := Stage(42).String(); s != "Stage(42)" {
+        t.Errorf("Wrong string, got: %s", s)
+    }
+}
+
+func TestVerificationError(t *testing.T) {
+    err := &VerificationError{Stage: StageSquareRoot, Reason: "could not enclose the square root"}
+
+    if s := err.Error(); s != "Verification failed in square root: could not enclose the square root" {
+        t.Errorf("Wrong message, got: %s", s)
+    }
+}
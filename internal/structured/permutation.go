@@ -0,0 +1,87 @@
+package structured
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+)
+
+// A permutation matrix, which maps the j-th unit vector to the perm[j]-th unit vector
+type Permutation struct {
+    perm []int
+    // inverse of perm, so that At does not have to search for the entry of a row
+    inverse []int
+}
+
+// returns the permutation matrix of perm or an error, if perm is not a permutation of 0, ..., len(perm) - 1
+func NewPermutation(perm []int) (*Permutation, error) {
+    if len(perm) == 0 {
+        return nil, fmt.Errorf("Permutation is empty")
+    }
+
+    inverse := make([]int, len(perm))
+
+    for i := range inverse {
+        inverse[i] = -1
+    }
+
+    for j, i := range perm {
+        if i < 0 || i >= len(perm) {
+            return nil, fmt.Errorf("Unexpected entry %d at %d (Expecting an entry in [0, %d))", i, j, len(perm))
+        }
+
+        if inverse[i] != -1 {
+            return nil, fmt.Errorf("Unexpected entry %d at %d (Already at %d)", i, j, inverse[i])
+        }
+
+        inverse[i] = j
+    }
+
+    p := make([]int, len(perm))
+    copy(p, perm)
+
+    return &Permutation{perm: p, inverse: inverse}, nil
+}
+
+// returns the exchange matrix of dimension (n, n), which has ones on the anti-diagonal and reverses the order of the coordinates
+func Exchange(n int) *Permutation {
+    checkDims(n, n)
+    perm := make([]int, n)
+
+    for j := range perm {
+        perm[j] = n - 1 - j
+    }
+
+    return &Permutation{perm: perm, inverse: perm}
+}
+
+// returns a copy of the permutation, which maps j to Permutation()[j]
+func (p *Permutation) Permutation() []int {
+    perm := make([]int, len(p.perm))
+    copy(perm, p.perm)
+    return perm
+}
+
+// returns Permutation()[j] without copying the permutation
+func (p *Permutation) Image(j int) int {
+    return p.perm[j]
+}
+
+// implements mat.Matrix
+func (p *Permutation) Dims() (r, c int) {
+    return len(p.perm), len(p.perm)
+}
+
+// implements mat.Matrix
+func (p *Permutation) At(i, j int) float64 {
+    checkIndex(len(p.perm), len(p.perm), i, j)
+
+    if p.perm[j] == i {
+        return 1
+    }
+    return 0
+}
+
+// implements mat.Matrix, the transpose is the inverse permutation
+func (p *Permutation) T() mat.Matrix {
+    return &Permutation{perm: p.inverse, inverse: p.perm}
+}
@@ -0,0 +1,85 @@
+package structured
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func TestNewPermutation(t *testing.T) {
+    p, err := NewPermutation([]int{1, 2, 0})
+
+    if err != nil {
+        t.Fatalf("NewPermutation returned unexpected error: %v", err)
+    }
+
+    expected := mat.NewDense(3, 3, []float64{0, 0, 1, 1, 0, 0, 0, 1, 0})
+
+    if !mat.Equal(p, expected) {
+        t.Errorf("Wrong permutation matrix, got: %v, want: %v", mat.Formatted(p), mat.Formatted(expected))
+    }
+
+    var product mat.Dense
+    product.Mul(p, p.T())
+
+    if !mat.Equal(&product, Identity(3)) {
+        t.Errorf("Transpose is not the inverse, got: %v", mat.Formatted(&product))
+    }
+
+    v := mat.NewVecDense(3, []float64{1, 2, 3})
+    v.MulVec(p, v)
+
+    if !mat.Equal(v, mat.NewVecDense(3, []float64{3, 1, 2})) {
+        t.Errorf("Wrong image of a vector, got: %v", mat.Formatted(v))
+    }
+
+    for j, image := range p.Permutation() {
+        if p.Image(j) != image {
+            t.Errorf("Wrong image of %d, got: %d, want: %d", j, p.Image(j), image)
+        }
+    }
+}
+
+func TestNewPermutationError(t *testing.T) {
+    tables := []struct {
+        desc string
+        perm []int
+        err error
+    }{
+        {desc: "empty", perm: []int{}, err: fmt.Errorf("Permutation is empty")},
+        {desc: "out of range", perm: []int{0, 2}, err: fmt.Errorf("Unexpected entry 2 at 1 (Expecting an entry in [0, 2))")},
+        {desc: "duplicate", perm: []int{1, 0, 1}, err: fmt.Errorf("Unexpected entry 1 at 2 (Already at 0)")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            p, err := NewPermutation(table.perm)
+
+            if !reflect.DeepEqual(err, table.err) || p != nil {
+                t.Errorf("NewPermutation returned wrong value, got: %v, %v, want: nil, %v", p, err, table.err)
+            }
+        })
+    }
+}
+
+func TestNewPermutationCopiesInput(t *testing.T) {
+    perm := []int{1, 0}
+    p, _ := NewPermutation(perm)
+    perm[0] = 0
+
+    if got := p.Permutation(); !reflect.DeepEqual(got, []int{1, 0}) {
+        t.Errorf("Permutation can be changed from outside, got: %v", got)
+    }
+}
+
+func TestExchange(t *testing.T) {
+    e := Exchange(3)
+    expected := mat.NewDense(3, 3, []float64{0, 0, 1, 0, 1, 0, 1, 0, 0})
+
+    if !mat.Equal(e, expected) || !mat.Equal(e.T(), expected) {
+        t.Errorf("Wrong exchange matrix, got: %v, want: %v", mat.Formatted(e), mat.Formatted(expected))
+    }
+}
@@ -0,0 +1,124 @@
+/*
+    Package structured provides structured matrices such as identities, shifts, embeddings and permutations.
+    The matrices only store their dimensions and structure, not their entries, and implement mat.Matrix,
+    so they can be used wherever gonum expects a matrix without allocating dense storage.
+*/
+package structured
+
+import (
+    "gonum.org/v1/gonum/mat"
+)
+
+func checkIndex(r, c, i, j int) {
+    if i < 0 || i >= r {
+        panic(mat.ErrRowAccess)
+    }
+
+    if j < 0 || j >= c {
+        panic(mat.ErrColAccess)
+    }
+}
+
+func checkDims(r, c int) {
+    if r <= 0 || c <= 0 {
+        if r == 0 || c == 0 {
+            panic(mat.ErrZeroLength)
+        }
+        panic(mat.ErrNegativeDimension)
+    }
+}
+
+// A matrix of dimension (r, c) with ones on a single diagonal and zeros everywhere else.
+// The entry (i, j) is one, if i == j + offset, so the matrix maps the j-th unit vector to the (j + offset)-th unit vector.
+type Embedding struct {
+    r, c int
+    offset int
+}
+
+// returns the identity matrix of dimension (n, n)
+func Identity(n int) *Embedding {
+    return Embed(n, n, 0)
+}
+
+// returns the matrix of dimension (r, c) with ones on the main diagonal. For r > c it embeds R^c into the first coordinates of R^r,
+// for r < c it projects R^c onto its first r coordinates
+func RectangularIdentity(r, c int) *Embedding {
+    return Embed(r, c, 0)
+}
+
+// returns the matrix of dimension (r, c) that maps the j-th unit vector to the (j + offset)-th unit vector, or to zero, if j + offset is out of range
+func Embed(r, c, offset int) *Embedding {
+    checkDims(r, c)
+    return &Embedding{r: r, c: c, offset: offset}
+}
+
+/*
+    returns the block shift of blocks square blocks of dimension blockSize, which has identities on the first block subdiagonal:
+        | 0 0 ... 0 0 |
+        | I 0 ... 0 0 |
+        | 0 I ... 0 0 |
+        | ...         |
+        | 0 0 ... I 0 |
+    These are the lower block rows of the unitary dilation, shifted by one block row.
+*/
+func BlockShift(blocks, blockSize int) *Embedding {
+    n := blocks * blockSize
+    return Embed(n, n, blockSize)
+}
+
+// implements mat.Matrix
+func (e *Embedding) Dims() (r, c int) {
+    return e.r, e.c
+}
+
+// implements mat.Matrix
+func (e *Embedding) At(i, j int) float64 {
+    checkIndex(e.r, e.c, i, j)
+
+    if i == j + e.offset {
+        return 1
+    }
+    return 0
+}
+
+// returns the submatrix of the rows i, ..., k - 1 and the columns j, ..., l - 1 of e, which is again an embedding.
+// Panics with mat.ErrIndexOutOfRange, if the rows or columns are out of range or empty
+func (e *Embedding) Slice(i, k, j, l int) *Embedding {
+    if i < 0 || k > e.r || i >= k || j < 0 || l > e.c || j >= l {
+        panic(mat.ErrIndexOutOfRange)
+    }
+
+    return &Embedding{r: k - i, c: l - j, offset: e.offset + j - i}
+}
+
+// implements mat.Matrix, the transpose is again an embedding
+func (e *Embedding) T() mat.Matrix {
+    return &Embedding{r: e.c, c: e.r, offset: -e.offset}
+}
+
+// A matrix of dimension (r, c) whose entries are all zero
+type Zero struct {
+    r, c int
+}
+
+// returns the zero matrix of dimension (r, c)
+func NewZero(r, c int) *Zero {
+    checkDims(r, c)
+    return &Zero{r: r, c: c}
+}
+
+// implements mat.Matrix
+func (z *Zero) Dims() (r, c int) {
+    return z.r, z.c
+}
+
+// implements mat.Matrix
+func (z *Zero) At(i, j int) float64 {
+    checkIndex(z.r, z.c, i, j)
+    return 0
+}
+
+// implements mat.Matrix
+func (z *Zero) T() mat.Matrix {
+    return &Zero{r: z.c, c: z.r}
+}
@@ -0,0 +1,118 @@
+package structured
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "testing"
+)
+
+func TestEmbedding(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        expected *mat.Dense
+    }{
+        {desc: "identity", value: Identity(3), expected: mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1})},
+        {desc: "rectangular identity with more rows", value: RectangularIdentity(3, 2), expected: mat.NewDense(3, 2, []float64{1, 0, 0, 1, 0, 0})},
+        {desc: "rectangular identity with more columns", value: RectangularIdentity(2, 3), expected: mat.NewDense(2, 3, []float64{1, 0, 0, 0, 1, 0})},
+        {desc: "embedding into the last coordinates", value: Embed(3, 2, 1), expected: mat.NewDense(3, 2, []float64{0, 0, 1, 0, 0, 1})},
+        {desc: "projection onto the last coordinates", value: Embed(2, 3, -1), expected: mat.NewDense(2, 3, []float64{0, 1, 0, 0, 0, 1})},
+        {desc: "block shift", value: BlockShift(3, 1), expected: mat.NewDense(3, 3, []float64{0, 0, 0, 1, 0, 0, 0, 1, 0})},
+        {desc: "transpose", value: Embed(3, 2, 1).T(), expected: mat.NewDense(2, 3, []float64{0, 1, 0, 0, 0, 1})},
+        {desc: "zero", value: NewZero(2, 3), expected: mat.NewDense(2, 3, nil)},
+        {desc: "transposed zero", value: NewZero(2, 3).T(), expected: mat.NewDense(3, 2, nil)},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            if !mat.Equal(table.value, table.expected) {
+                t.Errorf("Wrong matrix, got: %v, want: %v", mat.Formatted(table.value), mat.Formatted(table.expected))
+            }
+        })
+    }
+}
+
+func TestBlockShiftOfBlocks(t *testing.T) {
+    shift := BlockShift(3, 2)
+    var expected mat.Dense
+    expected.Kronecker(BlockShift(3, 1), Identity(2))
+
+    if !mat.Equal(shift, &expected) {
+        t.Errorf("Wrong block shift, got: %v, want: %v", mat.Formatted(shift), mat.Formatted(&expected))
+    }
+}
+
+func TestEmbeddingSlice(t *testing.T) {
+    shift := BlockShift(4, 2)
+    var expected mat.Dense
+    expected.CloneFrom(shift)
+
+    for _, r := range [][]int{{2, 4, 0, 2}, {4, 8, 0, 6}, {0, 3, 1, 8}, {7, 8, 7, 8}} {
+        if got := shift.Slice(r[0], r[1], r[2], r[3]); !mat.Equal(got, expected.Slice(r[0], r[1], r[2], r[3])) {
+            t.Errorf("Wrong slice %v, got: %v", r, mat.Formatted(got))
+        }
+    }
+
+    for _, r := range [][]int{{-1, 2, 0, 2}, {0, 9, 0, 2}, {2, 2, 0, 2}, {0, 2, 3, 1}} {
+        func() {
+            defer func() {
+                if err := recover(); err != mat.ErrIndexOutOfRange {
+                    t.Errorf("Slice %v did not panic with mat.ErrIndexOutOfRange, got: %v", r, err)
+                }
+            }()
+            shift.Slice(r[0], r[1], r[2], r[3])
+        }()
+    }
+}
+
+func TestAtPanicsOutOfRange(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        i, j int
+        expected error
+    }{
+        {desc: "embedding row", value: Identity(2), i: 2, j: 0, expected: mat.ErrRowAccess},
+        {desc: "embedding col", value: Identity(2), i: 0, j: -1, expected: mat.ErrColAccess},
+        {desc: "zero", value: NewZero(1, 2), i: 1, j: 0, expected: mat.ErrRowAccess},
+        {desc: "permutation", value: Exchange(2), i: 0, j: 2, expected: mat.ErrColAccess},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            defer func() {
+                if r := recover(); r != table.expected {
+                    t.Errorf("Wrong panic, got: %v, want: %v", r, table.expected)
+                }
+            }()
+            table.value.At(table.i, table.j)
+        })
+    }
+}
+
+func TestConstructorsPanicOnInvalidDimension(t *testing.T) {
+    tables := []struct {
+        desc string
+        construct func()
+        expected error
+    }{
+        {desc: "zero length", construct: func() { Identity(0) }, expected: mat.ErrZeroLength},
+        {desc: "negative dimension", construct: func() { NewZero(-1, 2) }, expected: mat.ErrNegativeDimension},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            defer func() {
+                if r := recover(); r != table.expected {
+                    t.Errorf("Wrong panic, got: %v, want: %v", r, table.expected)
+                }
+            }()
+            table.construct()
+        })
+    }
+}
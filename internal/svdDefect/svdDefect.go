@@ -0,0 +1,76 @@
+package svdDefect
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+/*
+    Both defect operators are determined by the singular value decomposition T = WΣVᵀ:
+    I - TᵀT = V(I - Σ²)Vᵀ and I - TTᵀ = W(I - Σ²)Wᵀ, hence
+    D_T = V sqrt(I - Σ²) Vᵀ and D_{Tᵀ} = W sqrt(I - Σ²) Wᵀ.
+    The square root of the diagonal matrix I - Σ² is taken entrywise, so a single factorization yields both defect operators.
+    As the largest singular value is the operator norm of T, the same factorization decides whether T is a contraction.
+*/
+
+func conjugate(vectors *mat.Dense, values []float64) *mat.Dense {
+    n, _ := vectors.Dims()
+    scaled := mat.NewDense(n, n, nil)
+    scaled.Apply(func(i, j int, v float64) float64 {
+        return v * values[j]
+    }, vectors)
+
+    conjugated := mat.NewDense(n, n, nil)
+    conjugated.Mul(scaled, vectors.T())
+    return conjugated
+}
+
+// returns sqrt(1 - σ²) for each singular value σ, padded with 1 up to dimension n
+func defectValues(singularValues []float64, n int) []float64 {
+    values := make([]float64, n)
+
+    for i := range values {
+        values[i] = 1
+    }
+
+    for i, sigma := range singularValues {
+        // (1 - σ)(1 + σ) does not cancel as badly as 1 - σ² for σ close to 1
+        values[i] = math.Sqrt((1 - sigma) * (1 + sigma))
+    }
+
+    return values
+}
+
+// Returns the defect operators D_T = sqrt(I - TᵀT) and D_{Tᵀ} = sqrt(I - TTᵀ) from one singular value decomposition of t or an error, if t is not a contraction.
+// A non-finite entry of either defect operator is reported as a *guard.NonFiniteError of StageSingularValueDecomposition
+func Calculate(t mat.Matrix) (defect, defectOfTransposed *mat.Dense, err error) {
+    var svd mat.SVD
+
+    if ok := svd.Factorize(t, mat.SVDFull); !ok {
+        return nil, nil, fmt.Errorf("svd: Factorize unsuccessful %v", mat.Formatted(t, mat.Prefix("    "), mat.Squeeze()))
+    }
+
+    singularValues := svd.Values(nil)
+
+    if len(singularValues) > 0 && singularValues[0] >= 1 {
+        return nil, nil, fmt.Errorf("Input is not a contraction")
+    }
+
+    m, n := t.Dims()
+    var w, v mat.Dense
+    svd.UTo(&w)
+    svd.VTo(&v)
+
+    defect = conjugate(&v, defectValues(singularValues, n))
+    defectOfTransposed = conjugate(&w, defectValues(singularValues, m))
+
+    for _, d := range []*mat.Dense{defect, defectOfTransposed} {
+        if err := guard.CheckFinite(guard.StageSingularValueDecomposition, d); err != nil {
+            return nil, nil, err
+        }
+    }
+
+    return
+}
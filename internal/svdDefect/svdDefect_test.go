@@ -0,0 +1,93 @@
+package svdDefect
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func defectSquared(t mat.Matrix) *mat.Dense {
+    _, n := t.Dims()
+    d := mat.NewDense(n, n, nil)
+    d.Mul(t.T(), t)
+    d.Scale(-1, d)
+
+    for i := 0; i < n; i++ {
+        d.Set(i, i, d.At(i, i) + 1)
+    }
+    return d
+}
+
+func TestCalculate(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+    }{
+        {value: mat.NewDense(2, 2, nil), desc: "for zero matrix"},
+        {value: mat.NewDense(2, 2, []float64{0.5,0,0,0.2,}), desc: "for a diagonal matrix"},
+        {value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), desc: "for a non normal matrix"},
+        {value: mat.NewDense(3, 3, []float64{0.1,0,0.3,0, 0.12, 0.1412,0, 0, 0.12}), desc: "for a non symmetric 3x3 matrix"},
+        {value: mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,}), desc: "for a symmetric matrix"},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            defect, defectOfTransposed, err := Calculate(table.value)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            for _, c := range []struct {
+                name string
+                sqrt *mat.Dense
+                squared *mat.Dense
+            }{
+                {name: "defect", sqrt: defect, squared: defectSquared(table.value)},
+                {name: "defect of transposed", sqrt: defectOfTransposed, squared: defectSquared(table.value.T())},
+            } {
+                var value mat.Dense
+                value.Mul(c.sqrt, c.sqrt)
+
+                if !mat.EqualApprox(&value, c.squared, 1e-12) {
+                    t.Errorf("Wrong %s, square is %v, want: %v", c.name, value, c.squared)
+                }
+
+                if !mat.EqualApprox(c.sqrt, c.sqrt.T(), 1e-14) {
+                    t.Errorf("Wrong %s, result is not symmetric: %v", c.name, c.sqrt)
+                }
+            }
+        })
+    }
+}
+
+func TestCalculateNoContraction(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+    }{
+        {value: mat.NewDense(2, 2, []float64{0.5,0,0,2,}), desc: "for a norm greater than 1"},
+        {value: mat.NewDense(2, 2, []float64{1,0,0,0.5,}), desc: "for a norm equal to 1"},
+        {value: mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,}), desc: "for entries smaller than 1"},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            defect, defectOfTransposed, err := Calculate(table.value)
+            expectedErr := fmt.Errorf("Input is not a contraction")
+
+            if !reflect.DeepEqual(err, expectedErr) {
+                t.Errorf("Wrong error, got: %v, want: %v", err, expectedErr)
+            }
+
+            if defect != nil || defectOfTransposed != nil {
+                t.Errorf("Unexpected result, got: %v, %v", defect, defectOfTransposed)
+            }
+        })
+    }
+}
@@ -0,0 +1,280 @@
+package interval
+
+import (
+    "errors"
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+)
+
+// A dense matrix of intervals, which encloses every real matrix whose entries are contained in the corresponding intervals
+type Dense struct {
+    rows, cols int
+    data []Interval
+}
+
+// returned by NewBlockMatrixFromSquares if there are no rows of blocks
+var ErrNoBlocks = errors.New("Block matrix has no blocks")
+
+func checkDims(r, c int) {
+    if r <= 0 || c <= 0 {
+        if r == 0 || c == 0 {
+            panic(mat.ErrZeroLength)
+        }
+        panic(mat.ErrNegativeDimension)
+    }
+}
+
+// returns the zero matrix of dimension (r, c)
+func NewDense(r, c int) *Dense {
+    checkDims(r, c)
+    return &Dense{rows: r, cols: c, data: make([]Interval, r * c)}
+}
+
+// returns the identity matrix of dimension (n, n)
+func Identity(n int) *Dense {
+    d := NewDense(n, n)
+
+    for i := 0; i < n; i++ {
+        d.data[i * n + i] = Point(1)
+    }
+
+    return d
+}
+
+// returns the matrix of the point intervals of the entries of m, which is exact
+func NewFromMatrix(m mat.Matrix) *Dense {
+    r, c := m.Dims()
+    d := NewDense(r, c)
+
+    for i := 0; i < r; i++ {
+        for j := 0; j < c; j++ {
+            d.data[i * c + j] = Point(m.At(i, j))
+        }
+    }
+
+    return d
+}
+
+func (d *Dense) Dims() (r, c int) {
+    return d.rows, d.cols
+}
+
+func (d *Dense) checkIndex(i, j int) {
+    if i < 0 || i >= d.rows || j < 0 || j >= d.cols {
+        panic(mat.ErrIndexOutOfRange)
+    }
+}
+
+func (d *Dense) At(i, j int) Interval {
+    d.checkIndex(i, j)
+    return d.data[i * d.cols + j]
+}
+
+func (d *Dense) Set(i, j int, v Interval) {
+    d.checkIndex(i, j)
+    d.data[i * d.cols + j] = v
+}
+
+// returns the transpose of d as a new matrix
+func (d *Dense) T() *Dense {
+    t := NewDense(d.cols, d.rows)
+
+    for i := 0; i < d.rows; i++ {
+        for j := 0; j < d.cols; j++ {
+            t.data[j * d.rows + i] = d.data[i * d.cols + j]
+        }
+    }
+
+    return t
+}
+
+// returns a copy of the rows i to k - 1 and the columns j to l - 1 of d
+func (d *Dense) Slice(i, k, j, l int) *Dense {
+    if i < 0 || k > d.rows || i >= k || j < 0 || l > d.cols || j >= l {
+        panic(mat.ErrIndexOutOfRange)
+    }
+
+    s := NewDense(k - i, l - j)
+
+    for r := i; r < k; r++ {
+        copy(s.data[(r - i) * s.cols:(r - i + 1) * s.cols], d.data[r * d.cols + j:r * d.cols + l])
+    }
+
+    return s
+}
+
+// returns the matrix of the midpoints of the entries of d
+func (d *Dense) Mid() *mat.Dense {
+    data := make([]float64, len(d.data))
+
+    for i, v := range d.data {
+        data[i] = v.Mid()
+    }
+
+    return mat.NewDense(d.rows, d.cols, data)
+}
+
+// reports whether every entry of m is contained in the corresponding interval of d
+func (d *Dense) Contains(m mat.Matrix) bool {
+    if r, c := m.Dims(); r != d.rows || c != d.cols {
+        return false
+    }
+
+    for i := 0; i < d.rows; i++ {
+        for j := 0; j < d.cols; j++ {
+            if !d.data[i * d.cols + j].Contains(m.At(i, j)) {
+                return false
+            }
+        }
+    }
+
+    return true
+}
+
+// returns the largest upper bound of the width of an entry of d
+func (d *Dense) MaxWidth() float64 {
+    var w float64
+
+    for _, v := range d.data {
+        if v.Width() > w {
+            w = v.Width()
+        }
+    }
+
+    return w
+}
+
+// returns an upper bound of the Frobenius norm of every matrix enclosed by d, which is also an upper bound of its operator norm
+func (d *Dense) NormBound() float64 {
+    sum := Point(0)
+
+    for _, v := range d.data {
+        sum = sum.Add(Point(v.Mag()).Sqr())
+    }
+
+    return sum.Sqrt().Hi
+}
+
+// returns a + b, panics with mat.ErrShape for different dimensions
+func Add(a, b *Dense) *Dense {
+    if a.rows != b.rows || a.cols != b.cols {
+        panic(mat.ErrShape)
+    }
+
+    sum := NewDense(a.rows, a.cols)
+
+    for i := range sum.data {
+        sum.data[i] = a.data[i].Add(b.data[i])
+    }
+
+    return sum
+}
+
+// returns a - b, panics with mat.ErrShape for different dimensions
+func Sub(a, b *Dense) *Dense {
+    if a.rows != b.rows || a.cols != b.cols {
+        panic(mat.ErrShape)
+    }
+
+    diff := NewDense(a.rows, a.cols)
+
+    for i := range diff.data {
+        diff.data[i] = a.data[i].Sub(b.data[i])
+    }
+
+    return diff
+}
+
+// returns f * a
+func Scale(f Interval, a *Dense) *Dense {
+    scaled := NewDense(a.rows, a.cols)
+
+    for i := range scaled.data {
+        scaled.data[i] = f.Mul(a.data[i])
+    }
+
+    return scaled
+}
+
+// returns a * b, panics with mat.ErrShape if the columns of a do not match the rows of b
+func Mul(a, b *Dense) *Dense {
+    if a.cols != b.rows {
+        panic(mat.ErrShape)
+    }
+
+    product := NewDense(a.rows, b.cols)
+
+    for i := 0; i < a.rows; i++ {
+        for j := 0; j < b.cols; j++ {
+            sum := Point(0)
+
+            for k := 0; k < a.cols; k++ {
+                sum = sum.Add(a.data[i * a.cols + k].Mul(b.data[k * b.cols + j]))
+            }
+
+            product.data[i * b.cols + j] = sum
+        }
+    }
+
+    return product
+}
+
+// returns an enclosure of vᵀ A v for every matrix A enclosed by d, panics with mat.ErrShape if v does not match the dimension of d
+func QuadraticForm(d *Dense, v []float64) Interval {
+    if d.rows != d.cols || d.rows != len(v) {
+        panic(mat.ErrShape)
+    }
+
+    sum := Point(0)
+
+    for i := 0; i < d.rows; i++ {
+        for j := 0; j < d.cols; j++ {
+            sum = sum.Add(Point(v[i]).Mul(d.data[i * d.cols + j]).Mul(Point(v[j])))
+        }
+    }
+
+    return sum
+}
+
+// returns the block matrix of the square blocks in rows, which need to have the same dimension
+func NewBlockMatrixFromSquares(rows [][]*Dense) (*Dense, error) {
+    n0 := len(rows)
+
+    if n0 == 0 {
+        return nil, ErrNoBlocks
+    }
+
+    var d0 int
+
+    for i, row := range rows {
+        if n := len(row); n != n0 {
+            return nil, fmt.Errorf("Unexpected length of row: %d has length %d (Expecting %d)", i, n, n0)
+        }
+
+        for j, block := range row {
+            if block == nil {
+                return nil, fmt.Errorf("Unexpected nil block in row %d, col %d", i, j)
+            }
+
+            if i == 0 && j == 0 {
+                d0, _ = block.Dims()
+            }
+
+            if d1, d2 := block.Dims(); d1 != d0 || d2 != d0 {
+                return nil, fmt.Errorf("Unexpected dimension: (%d, %d) in row %d, col %d (Expecting (%d, %d))", d1, d2, i, j, d0, d0)
+            }
+        }
+    }
+
+    d := NewDense(d0 * n0, d0 * n0)
+
+    for i, row := range rows {
+        for j, block := range row {
+            for r := 0; r < d0; r++ {
+                copy(d.data[(i * d0 + r) * d.cols + j * d0:(i * d0 + r) * d.cols + (j + 1) * d0], block.data[r * d0:(r + 1) * d0])
+            }
+        }
+    }
+
+    return d, nil
+}
@@ -0,0 +1,64 @@
+package interval
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func TestMulEnclosesProduct(t *testing.T) {
+    a := mat.NewDense(2, 3, []float64{0.1, 0.2, 0.3, -0.4, 0.5, 0.6})
+    b := mat.NewDense(3, 2, []float64{0.7, -0.8, 0.9, 1.1, 1.3, 1.7})
+    var expected mat.Dense
+    expected.Mul(a, b)
+
+    product := Mul(NewFromMatrix(a), NewFromMatrix(b))
+
+    if !product.Contains(&expected) {
+        t.Errorf("Product %v does not contain %v", product, mat.Formatted(&expected))
+    }
+
+    if w := product.MaxWidth(); w > 1e-14 {
+        t.Errorf("Enclosure too wide: %v", w)
+    }
+}
+
+func TestNormBound(t *testing.T) {
+    d := NewFromMatrix(mat.NewDense(2, 2, []float64{3, 0, 0, -4}))
+    d.Set(0, 1, Interval{Lo: -1, Hi: 0})
+
+    if bound := d.NormBound(); bound < 5.0990195135927845 || bound > 5.1 {
+        t.Errorf("Wrong bound: %v", bound)
+    }
+}
+
+func TestSliceAndTranspose(t *testing.T) {
+    d := NewFromMatrix(mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}))
+
+    if s := d.Slice(0, 2, 1, 3).Mid(); !mat.Equal(s, mat.NewDense(2, 2, []float64{2, 3, 5, 6})) {
+        t.Errorf("Wrong slice: %v", mat.Formatted(s))
+    }
+
+    if tr := d.T().Mid(); !mat.Equal(tr, mat.NewDense(3, 2, []float64{1, 4, 2, 5, 3, 6})) {
+        t.Errorf("Wrong transpose: %v", mat.Formatted(tr))
+    }
+}
+
+func TestNewBlockMatrixFromSquares(t *testing.T) {
+    a := NewFromMatrix(mat.NewDense(1, 1, []float64{1}))
+    b := NewFromMatrix(mat.NewDense(1, 1, []float64{2}))
+
+    d, err := NewBlockMatrixFromSquares([][]*Dense{{a, b}, {b, a}})
+
+    if err != nil || !mat.Equal(d.Mid(), mat.NewDense(2, 2, []float64{1, 2, 2, 1})) {
+        t.Errorf("Wrong block matrix, got: %v, %v", d, err)
+    }
+
+    if _, err := NewBlockMatrixFromSquares(nil); !reflect.DeepEqual(err, ErrNoBlocks) {
+        t.Errorf("Wrong error: %v", err)
+    }
+
+    if _, err := NewBlockMatrixFromSquares([][]*Dense{{a, nil}, {a, a}}); err == nil {
+        t.Errorf("Expected error for nil block")
+    }
+}
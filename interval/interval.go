@@ -0,0 +1,105 @@
+/*
+    Package interval provides intervals and matrices of intervals with outward rounding, so every result encloses the exact result
+    of the operation for all values of its operands. It is used to verify calculations in float64 rigorously.
+*/
+package interval
+
+import (
+    "math"
+)
+
+// The closed interval [Lo, Hi] of real numbers
+type Interval struct {
+    Lo, Hi float64
+}
+
+// rounds x to the next smaller float64, which is a lower bound of the exact result of the operation that was rounded to nearest
+func down(x float64) float64 {
+    return math.Nextafter(x, math.Inf(-1))
+}
+
+// rounds x to the next larger float64, which is an upper bound of the exact result of the operation that was rounded to nearest
+func up(x float64) float64 {
+    return math.Nextafter(x, math.Inf(1))
+}
+
+// returns the interval [x, x]
+func Point(x float64) Interval {
+    return Interval{Lo: x, Hi: x}
+}
+
+// returns the smallest interval that contains x and y
+func Hull(x, y float64) Interval {
+    return Interval{Lo: math.Min(x, y), Hi: math.Max(x, y)}
+}
+
+func (a Interval) Add(b Interval) Interval {
+    return Interval{Lo: down(a.Lo + b.Lo), Hi: up(a.Hi + b.Hi)}
+}
+
+func (a Interval) Sub(b Interval) Interval {
+    return Interval{Lo: down(a.Lo - b.Hi), Hi: up(a.Hi - b.Lo)}
+}
+
+func (a Interval) Mul(b Interval) Interval {
+    p1, p2, p3, p4 := a.Lo * b.Lo, a.Lo * b.Hi, a.Hi * b.Lo, a.Hi * b.Hi
+    return Interval{Lo: down(math.Min(math.Min(p1, p2), math.Min(p3, p4))), Hi: up(math.Max(math.Max(p1, p2), math.Max(p3, p4)))}
+}
+
+// returns a / b, which is [-Inf, Inf], if b contains 0
+func (a Interval) Div(b Interval) Interval {
+    if b.Contains(0) {
+        return Interval{Lo: math.Inf(-1), Hi: math.Inf(1)}
+    }
+
+    q1, q2, q3, q4 := a.Lo / b.Lo, a.Lo / b.Hi, a.Hi / b.Lo, a.Hi / b.Hi
+    return Interval{Lo: down(math.Min(math.Min(q1, q2), math.Min(q3, q4))), Hi: up(math.Max(math.Max(q1, q2), math.Max(q3, q4)))}
+}
+
+// returns a², which is non-negative unlike a.Mul(a) for an interval that contains 0
+func (a Interval) Sqr() Interval {
+    if a.Contains(0) {
+        return Interval{Lo: 0, Hi: up(math.Max(a.Lo * a.Lo, a.Hi * a.Hi))}
+    }
+
+    return a.Mul(a)
+}
+
+// returns the square root of the non-negative part of a
+func (a Interval) Sqrt() Interval {
+    return Interval{Lo: math.Max(down(math.Sqrt(math.Max(a.Lo, 0))), 0), Hi: up(math.Sqrt(math.Max(a.Hi, 0)))}
+}
+
+// returns max |x| for x in a
+func (a Interval) Mag() float64 {
+    return math.Max(math.Abs(a.Lo), math.Abs(a.Hi))
+}
+
+// returns min |x| for x in a, which is 0, if a contains 0
+func (a Interval) Mig() float64 {
+    if a.Contains(0) {
+        return 0
+    }
+
+    return math.Min(math.Abs(a.Lo), math.Abs(a.Hi))
+}
+
+func (a Interval) Contains(x float64) bool {
+    return a.Lo <= x && x <= a.Hi
+}
+
+// returns the midpoint of a, which is contained in a
+func (a Interval) Mid() float64 {
+    m := a.Lo + (a.Hi - a.Lo) / 2
+
+    if !a.Contains(m) {
+        return a.Lo
+    }
+
+    return m
+}
+
+// returns an upper bound of Hi - Lo
+func (a Interval) Width() float64 {
+    return up(a.Hi - a.Lo)
+}
@@ -0,0 +1,74 @@
+package interval
+
+import (
+    "math"
+    "testing"
+)
+
+func TestOperationsEncloseExactResult(t *testing.T) {
+    third := Point(1).Div(Point(3))
+    tables := []struct {
+        desc string
+        value Interval
+        contains []float64
+        excludes []float64
+    }{
+        {desc: "sum", value: Point(0.1).Add(Point(0.2)), contains: []float64{0.30000000000000004, 0.3}},
+        {desc: "difference", value: Interval{Lo: 1, Hi: 2}.Sub(Interval{Lo: 0.5, Hi: 1}), contains: []float64{0, 1.5}, excludes: []float64{-0.01, 1.51}},
+        {desc: "product of mixed signs", value: Interval{Lo: -2, Hi: 3}.Mul(Interval{Lo: -1, Hi: 4}), contains: []float64{-8, 12}, excludes: []float64{-8.01, 12.01}},
+        {desc: "quotient", value: third, contains: []float64{1.0 / 3}, excludes: []float64{0.3334}},
+        {desc: "quotient by interval containing 0", value: Point(1).Div(Interval{Lo: -1, Hi: 1}), contains: []float64{math.Inf(-1), math.Inf(1)}},
+        {desc: "square of interval containing 0", value: Interval{Lo: -2, Hi: 1}.Sqr(), contains: []float64{0, 4}, excludes: []float64{-0.01}},
+        {desc: "square root", value: Point(2).Sqrt(), contains: []float64{math.Sqrt2}},
+        {desc: "square root of non-negative part", value: Interval{Lo: -1, Hi: 4}.Sqrt(), contains: []float64{0, 2}, excludes: []float64{-0.01}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            for _, x := range table.contains {
+                if !table.value.Contains(x) {
+                    t.Errorf("%v does not contain %v", table.value, x)
+                }
+            }
+
+            for _, x := range table.excludes {
+                if table.value.Contains(x) {
+                    t.Errorf("%v contains %v", table.value, x)
+                }
+            }
+        })
+    }
+}
+
+func TestRoundingIsOutward(t *testing.T) {
+    // 0.1 + 0.2 is not exactly representable, so the enclosure must not collapse to a point
+    a, b := 0.1, 0.2
+    sum := Point(a).Add(Point(b))
+
+    if sum.Lo >= a + b || sum.Hi <= a + b {
+        t.Errorf("Rounding is not outward: %v", sum)
+    }
+
+    if w := sum.Width(); w <= 0 || w > 1e-15 {
+        t.Errorf("Unexpected width: %v", w)
+    }
+}
+
+func TestMidAndMag(t *testing.T) {
+    a := Interval{Lo: -3, Hi: 1}
+
+    if a.Mid() != -1 || a.Mag() != 3 {
+        t.Errorf("Wrong midpoint or magnitude, got: %v, %v", a.Mid(), a.Mag())
+    }
+
+    if a.Mig() != 0 || (Interval{Lo: -3, Hi: -2}).Mig() != 2 {
+        t.Errorf("Wrong mignitude, got: %v, %v", a.Mig(), (Interval{Lo: -3, Hi: -2}).Mig())
+    }
+
+    if h := Hull(2, -1); h != (Interval{Lo: -1, Hi: 2}) {
+        t.Errorf("Wrong hull: %v", h)
+    }
+}
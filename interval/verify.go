@@ -0,0 +1,201 @@
+package interval
+
+import (
+    "gonum.org/v1/gonum/mat"
+)
+
+/*
+    Reports whether every symmetric matrix enclosed by d is positive definite, which is proven by an interval Cholesky decomposition
+    of the lower triangle of d whose pivots are all positive. A false result does not prove that d encloses a matrix that is not positive definite.
+    Panics with mat.ErrShape for a non-square d.
+*/
+func IsPositiveDefinite(d *Dense) bool {
+    if d.rows != d.cols {
+        panic(mat.ErrShape)
+    }
+
+    n := d.rows
+    l := NewDense(n, n)
+
+    for j := 0; j < n; j++ {
+        pivot := d.data[j * n + j]
+
+        for k := 0; k < j; k++ {
+            pivot = pivot.Sub(l.data[j * n + k].Sqr())
+        }
+
+        if pivot.Lo <= 0 {
+            return false
+        }
+
+        l.data[j * n + j] = pivot.Sqrt()
+
+        for i := j + 1; i < n; i++ {
+            sum := d.data[i * n + j]
+
+            for k := 0; k < j; k++ {
+                sum = sum.Sub(l.data[i * n + k].Mul(l.data[j * n + k]))
+            }
+
+            l.data[i * n + j] = sum.Div(l.data[j * n + j])
+        }
+    }
+
+    return true
+}
+
+/*
+    Returns the sign of the determinant of every matrix enclosed by d and true, if it is proven by an interval Gaussian elimination
+    with partial pivoting whose pivots all exclude 0: the determinant is the product of the pivots and the sign of the row exchanges.
+    Returns false, if a pivot contains 0, which does not prove that d encloses a singular matrix. Panics with mat.ErrShape for a non-square d.
+*/
+func DeterminantSign(d *Dense) (int, bool) {
+    if d.rows != d.cols {
+        panic(mat.ErrShape)
+    }
+
+    n := d.rows
+    a := make([]Interval, len(d.data))
+    copy(a, d.data)
+    sign := 1
+
+    for j := 0; j < n; j++ {
+        // the pivot with the largest lower bound of its absolute value
+        p := j
+
+        for i := j + 1; i < n; i++ {
+            if a[i * n + j].Mig() > a[p * n + j].Mig() {
+                p = i
+            }
+        }
+
+        pivot := a[p * n + j]
+
+        if pivot.Contains(0) {
+            return 0, false
+        }
+
+        if p != j {
+            for k := j; k < n; k++ {
+                a[j * n + k], a[p * n + k] = a[p * n + k], a[j * n + k]
+            }
+            sign = -sign
+        }
+
+        if pivot.Hi < 0 {
+            sign = -sign
+        }
+
+        for i := j + 1; i < n; i++ {
+            factor := a[i * n + j].Div(pivot)
+
+            for k := j + 1; k < n; k++ {
+                a[i * n + k] = a[i * n + k].Sub(factor.Mul(a[j * n + k]))
+            }
+        }
+    }
+
+    return sign, true
+}
+
+// returns d - μI
+func shifted(d *Dense, mu float64) *Dense {
+    return Sub(d, Scale(Point(mu), Identity(d.rows)))
+}
+
+// returns an approximation of the smallest eigenvalue and its eigenvector of the symmetric part of the midpoint of d
+func smallestEigenvalue(d *Dense) (float64, []float64) {
+    n := d.rows
+    mid := d.Mid()
+    sym := mat.NewSymDense(n, nil)
+
+    for i := 0; i < n; i++ {
+        for j := i; j < n; j++ {
+            sym.SetSym(i, j, (mid.At(i, j) + mid.At(j, i)) / 2)
+        }
+    }
+
+    var eigen mat.EigenSym
+
+    if !eigen.Factorize(sym, true) {
+        return 0, nil
+    }
+
+    var vectors mat.Dense
+    eigen.VectorsTo(&vectors)
+    // the eigenvalues are in ascending order
+    return eigen.Values(nil)[0], mat.Col(nil, 0, &vectors)
+}
+
+/*
+    Returns a lower bound μ ≥ 0 of the smallest eigenvalue of every symmetric matrix enclosed by d and true, if every such matrix is proven
+    positive definite. μ is a fraction of the approximate smallest eigenvalue, for which d - μI is proven positive definite, and 0, if only d itself is.
+    Returns false, if d could not be proven positive definite. Panics with mat.ErrShape for a non-square d.
+*/
+func LowerEigenvalueBound(d *Dense) (float64, bool) {
+    if d.rows != d.cols {
+        panic(mat.ErrShape)
+    }
+
+    if lambda, _ := smallestEigenvalue(d); lambda > 0 {
+        for _, f := range []float64{0.9, 0.5, 0.1, 0.01} {
+            if mu := f * lambda; IsPositiveDefinite(shifted(d, mu)) {
+                return mu, true
+            }
+        }
+    }
+
+    return 0, IsPositiveDefinite(d)
+}
+
+/*
+    Reports whether d encloses only matrices that are not positive definite, which is proven by vᵀ A v ≤ 0 for the approximate eigenvector v
+    of the smallest eigenvalue and every matrix A enclosed by d. A false result does not prove that d encloses a positive definite matrix.
+*/
+func IsNotPositiveDefinite(d *Dense) bool {
+    if d.rows != d.cols {
+        panic(mat.ErrShape)
+    }
+
+    _, v := smallestEigenvalue(d)
+    return v != nil && QuadraticForm(d, v).Hi <= 0
+}
+
+/*
+    Returns an enclosure of the positive semidefinite square root S of every symmetric positive semidefinite matrix C enclosed by c,
+    centered at the symmetric part X of approx, or false, if X could not be proven positive definite.
+    For symmetric X ≥ μI with μ > 0, X - S solves the Sylvester equation X(X - S) + (X - S)S = X² - C, so X - S is the integral of
+    exp(-tX)(X² - C)exp(-tS) over t ≥ 0 and ‖X - S‖_F ≤ ‖X² - C‖_F / μ, which bounds every entry of X - S.
+*/
+func SqrtEnclosure(c *Dense, approx mat.Matrix) (*Dense, bool) {
+    n, _ := approx.Dims()
+
+    if c.rows != c.cols || c.rows != n {
+        panic(mat.ErrShape)
+    }
+
+    x := NewDense(n, n)
+
+    for i := 0; i < n; i++ {
+        for j := 0; j < n; j++ {
+            // rounded the same way for (i, j) and (j, i), so X is exactly symmetric
+            x.data[i * n + j] = Point((approx.At(i, j) + approx.At(j, i)) / 2)
+        }
+    }
+
+    mu, ok := LowerEigenvalueBound(x)
+
+    if !ok || mu <= 0 {
+        return nil, false
+    }
+
+    residual := Sub(Mul(x, x), c).NormBound()
+    delta := Point(residual).Div(Point(mu)).Hi
+    radius := Interval{Lo: -delta, Hi: delta}
+
+    for i := range x.data {
+        x.data[i] = x.data[i].Add(radius)
+    }
+
+    return x, true
+}
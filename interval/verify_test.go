@@ -0,0 +1,111 @@
+package interval
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "testing"
+)
+
+func TestIsPositiveDefinite(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *Dense
+        expected bool
+    }{
+        {desc: "identity", value: Identity(3), expected: true},
+        {desc: "positive definite", value: NewFromMatrix(mat.NewDense(2, 2, []float64{2, -1, -1, 2})), expected: true},
+        {desc: "singular", value: NewFromMatrix(mat.NewDense(2, 2, []float64{1, 1, 1, 1})), expected: false},
+        {desc: "indefinite", value: NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 2, 1})), expected: false},
+        {desc: "enclosing a singular matrix", value: &Dense{rows: 1, cols: 1, data: []Interval{{Lo: 0, Hi: 1}}}, expected: false},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            if pd := IsPositiveDefinite(table.value); pd != table.expected {
+                t.Errorf("Wrong result, got: %t, want: %t", pd, table.expected)
+            }
+        })
+    }
+}
+
+func TestLowerEigenvalueBound(t *testing.T) {
+    // the eigenvalues are 1 and 3
+    mu, ok := LowerEigenvalueBound(NewFromMatrix(mat.NewDense(2, 2, []float64{2, -1, -1, 2})))
+
+    if !ok || mu <= 0 || mu > 1 {
+        t.Errorf("Wrong bound, got: %v, %t", mu, ok)
+    }
+
+    if _, ok := LowerEigenvalueBound(NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 2, 1}))); ok {
+        t.Errorf("Indefinite matrix proven positive definite")
+    }
+}
+
+func TestIsNotPositiveDefinite(t *testing.T) {
+    if !IsNotPositiveDefinite(NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 2, 1}))) {
+        t.Errorf("Indefinite matrix not proven")
+    }
+
+    if IsNotPositiveDefinite(Identity(2)) {
+        t.Errorf("Identity proven not positive definite")
+    }
+}
+
+func TestDeterminantSign(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *Dense
+        sign int
+        ok bool
+    }{
+        {desc: "identity", value: Identity(3), sign: 1, ok: true},
+        {desc: "exchange of two coordinates", value: NewFromMatrix(mat.NewDense(3, 3, []float64{0, 1, 0, 1, 0, 0, 0, 0, 1})), sign: -1, ok: true},
+        {desc: "rotation", value: NewFromMatrix(mat.NewDense(2, 2, []float64{0.6, -0.8, 0.8, 0.6})), sign: 1, ok: true},
+        {desc: "reflection", value: NewFromMatrix(mat.NewDense(2, 2, []float64{0.6, 0.8, 0.8, -0.6})), sign: -1, ok: true},
+        {desc: "negative pivot", value: NewFromMatrix(mat.NewDense(2, 2, []float64{-2, 1, 1, 1})), sign: -1, ok: true},
+        {desc: "singular", value: NewFromMatrix(mat.NewDense(2, 2, []float64{1, 2, 2, 4})), ok: false},
+        // the determinant is in [-1e-10, 1e-10]
+        {desc: "enclosing both signs", value: &Dense{rows: 2, cols: 2, data: []Interval{Point(1), Point(1), Point(1), {Lo: 1 - 1e-10, Hi: 1 + 1e-10}}}, ok: false},
+        {desc: "enclosing one sign", value: &Dense{rows: 2, cols: 2, data: []Interval{Point(1), Point(1), Point(1), {Lo: 1 + 1e-10, Hi: 1 + 2e-10}}}, sign: 1, ok: true},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            if sign, ok := DeterminantSign(table.value); sign != table.sign || ok != table.ok {
+                t.Errorf("Wrong result, got: %d, %t, want: %d, %t", sign, ok, table.sign, table.ok)
+            }
+        })
+    }
+}
+
+func TestSqrtEnclosure(t *testing.T) {
+    // the square root of [[5, 4], [4, 5]] is [[2, 1], [1, 2]]
+    c := NewFromMatrix(mat.NewDense(2, 2, []float64{5, 4, 4, 5}))
+    approx := mat.NewDense(2, 2, []float64{2 + 1e-9, 1, 1 - 1e-9, 2})
+    expected := mat.NewDense(2, 2, []float64{2, 1, 1, 2})
+
+    enclosure, ok := SqrtEnclosure(c, approx)
+
+    if !ok || !enclosure.Contains(expected) {
+        t.Fatalf("Square root not enclosed, got: %v, %t", enclosure, ok)
+    }
+
+    if w := enclosure.MaxWidth(); w > 1e-7 {
+        t.Errorf("Enclosure too wide: %v", w)
+    }
+
+    // an indefinite square root of the identity is not enclosed
+    if _, ok := SqrtEnclosure(Identity(2), mat.NewDense(2, 2, []float64{1, 0, 0, -1})); ok {
+        t.Errorf("Enclosed an indefinite square root")
+    }
+
+    if _, ok := SqrtEnclosure(&Dense{rows: 1, cols: 1, data: []Interval{Point(2)}}, mat.NewDense(1, 1, []float64{math.Sqrt2})); !ok {
+        t.Errorf("Square root of 2 not enclosed")
+    }
+}
@@ -0,0 +1,87 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+/*
+    KroneckerDilation is the unitary n-dilation of a tensor product T1 ⊗ T2 combined from the dilations U1 and U2 of its factors:
+    U1 ⊗ U2 is unitary and its powers compress to T1^k ⊗ T2^k on the span of the first blocks of U1 and U2, which Permutation moves to the front.
+    It implements mat.Matrix without storing the combined dilation, entries are calculated from the factors on access.
+*/
+type KroneckerDilation struct {
+    // the unitary n-dilation U1 of t1
+    First *mat.Dense
+    // the unitary n-dilation U2 of t2
+    Second *mat.Dense
+    // the dilation is Pᵀ (U1 ⊗ U2) P for the permutation matrix P, i.e. index i of the dilation is index Permutation.Image(i) of U1 ⊗ U2
+    Permutation *Permutation
+    // an upper bound of ‖UᵀU - I‖_F, calculated from the factors
+    UnitarityBound float64
+    // CompressionBounds[k - 1] is an upper bound of ‖P U^k Pᵀ - (T1 ⊗ T2)^k‖_F for k = 1, ..., n, calculated from the factors
+    CompressionBounds []float64
+}
+
+// returns the dimension m1 m2 (n + 1)² of the dilation
+func (k *KroneckerDilation) Dims() (r, c int) {
+    return k.Permutation.Dims()
+}
+
+// returns the entry (i, j) of the dilation, which is the product of an entry of each factor
+func (k *KroneckerDilation) At(i, j int) float64 {
+    n2, _ := k.Second.Dims()
+    r, c := k.Permutation.Image(i), k.Permutation.Image(j)
+    return k.First.At(r / n2, c / n2) * k.Second.At(r % n2, c % n2)
+}
+
+func (k *KroneckerDilation) T() mat.Matrix {
+    return mat.Transpose{Matrix: k}
+}
+
+// returns the dilation as *mat.Dense, which needs the memory that KroneckerDilation avoids
+func (k *KroneckerDilation) Dense() *mat.Dense {
+    return mat.DenseCopyOf(k)
+}
+
+/*
+    Returns a unitary n-dilation of the tensor product t1 ⊗ t2 of two square contractions, combined from UnitaryNDilation of each factor,
+    so I - (T1 ⊗ T2)(T1 ⊗ T2)ᵀ is never formed and only square roots of the dimension of the factors are calculated.
+    Its upper left block of dimension m1 m2 is t1 ⊗ t2 in the order of mat.Dense.Kronecker, but it has dimension m1 m2 (n + 1)² instead of m1 m2 (n + 1).
+    The combined dilation is checked to be unitary and to compress to the powers of t1 ⊗ t2 up to 1e-8 per dimension of t1 ⊗ t2,
+    a *VerificationError with StageStructureCheck is returned otherwise.
+    Unlike UnitaryNDilation, both factors must be square, a rectangular factor results in the error "Matrix does not have square dimension".
+*/
+func UnitaryNDilationKronecker(t1, t2 mat.Matrix, n int, opts ...Option) (kronecker *KroneckerDilation, err error) {
+    defer guard.Recover(&err)
+
+    for _, t := range []mat.Matrix{t1, t2} {
+        if err := validate(t, n); err != nil {
+            return nil, err
+        }
+    }
+
+    o := newOptions(opts)
+
+    // each factor is projected, so the dilation is one of the tensor product of the projections
+    if t1, err = o.projected(t1); err != nil {
+        return nil, err
+    }
+
+    if t2, err = o.projected(t2); err != nil {
+        return nil, err
+    }
+
+    dilate := func(t mat.Matrix) (*mat.Dense, error) {
+        return o.dilate(t, n)
+    }
+
+    u1, u2, perm, unitarity, compression, err := dilation.UnitaryNDilationKronecker(dilate, t1, t2, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    return &KroneckerDilation{First: u1, Second: u2, Permutation: perm, UnitarityBound: unitarity, CompressionBounds: compression}, nil
+}
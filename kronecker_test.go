@@ -0,0 +1,86 @@
+package godilation
+
+import (
+    "errors"
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "reflect"
+    "testing"
+)
+
+func TestUnitaryNDilationKronecker(t *testing.T) {
+    backends := []struct {
+        desc string
+        backend Backend
+        tol float64
+    }{
+        {desc: "exponential method", backend: ExponentialMethod, tol: 1e-8},
+        {desc: "singular value decomposition", backend: SingularValueDecomposition, tol: 1e-12},
+    }
+    t1 := mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,})
+    t2 := mat.NewDense(3, 3, []float64{0.1,0,0.3,0,0.12,0.1412,0,0,0.12,})
+    degree := 2
+
+    var product mat.Dense
+    product.Kronecker(t1, t2)
+
+    for _, backend := range backends {
+        backend := backend
+        t.Run(backend.desc, func(t *testing.T) {
+            t.Parallel()
+            kronecker, err := UnitaryNDilationKronecker(t1, t2, degree, WithBackend(backend.backend))
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if r, c := kronecker.Dims(); r != 6 * 9 || c != 6 * 9 {
+                t.Errorf("Wrong dimension, got: (%d, %d), want: (54, 54)", r, c)
+            }
+
+            unitary := kronecker.Dense()
+            isNDilation(t, unitary, &product, degree, backend.tol)
+
+            if !mat.Equal(kronecker.T(), unitary.T()) {
+                t.Errorf("Wrong transpose")
+            }
+
+            if kronecker.UnitarityBound > backend.tol || len(kronecker.CompressionBounds) != degree {
+                t.Errorf("Wrong bounds, got: %v, %v", kronecker.UnitarityBound, kronecker.CompressionBounds)
+            }
+
+            if deviation := unitarityDeviation(unitary); deviation > kronecker.UnitarityBound + 1e-13 {
+                t.Errorf("Unitarity bound does not hold, got: %v, bound: %v", deviation, kronecker.UnitarityBound)
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationKroneckerErrors(t *testing.T) {
+    value := mat.NewDense(1, 1, []float64{0.5})
+
+    tables := []struct {
+        desc string
+        t1, t2 mat.Matrix
+        degree int
+        expected error
+    }{
+        {desc: "nil first factor", t1: nil, t2: value, degree: 1, expected: ErrNilMatrix},
+        {desc: "nil second factor", t1: value, t2: nil, degree: 1, expected: ErrNilMatrix},
+        {desc: "invalid degree", t1: value, t2: value, degree: 0, expected: &DegreeError{Degree: 0}},
+        {desc: "rectangular factor", t1: value, t2: mat.NewDense(1, 2, nil), degree: 1, expected: fmt.Errorf("Matrix does not have square dimension")},
+        {desc: "factor that is not a contraction", t1: mat.NewDense(1, 1, []float64{2}), t2: value, degree: 1, expected: fmt.Errorf("Input is not a contraction")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            kronecker, err := UnitaryNDilationKronecker(table.t1, table.t2, table.degree)
+
+            if kronecker != nil || !(errors.Is(err, table.expected) || reflect.DeepEqual(err, table.expected)) {
+                t.Errorf("Unexpected result, got: %v, %v, want: nil, %v", kronecker, err, table.expected)
+            }
+        })
+    }
+}
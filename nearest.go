@@ -0,0 +1,114 @@
+package godilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+// returned for a maximal norm of NearestContraction or WithNearestContraction outside of [0, 1)
+type MaxNormError struct {
+    MaxNorm float64
+}
+
+func (e *MaxNormError) Error() string {
+    return fmt.Sprintf("Unexpected maximal norm: %v (Expecting a norm in [0, 1))", e.MaxNorm)
+}
+
+// returned for an option that a function does not support, e.g. WithNearestContraction for an exact rational contraction
+type OptionError struct {
+    Option string
+    Reason string
+}
+
+func (e *OptionError) Error() string {
+    return fmt.Sprintf("Unsupported option %s: %s", e.Option, e.Reason)
+}
+
+/*
+    Returns the matrix with the singular values of t clipped to maxNorm and the Frobenius distance to t.
+    With the singular value decomposition T = WΣVᵀ, the result W min(Σ, maxNorm) Vᵀ is the nearest matrix with operator norm at most maxNorm
+    in the Frobenius and in the operator norm. The distance is sqrt(Σ (σ_i - maxNorm)²) over the clipped singular values,
+    so a t with ‖T‖₂ ≤ maxNorm is returned as copy with distance 0. maxNorm must be in [0, 1), so the result is a contraction.
+*/
+func NearestContraction(t mat.Matrix, maxNorm float64) (nearest *mat.Dense, distance float64, err error) {
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(t); err != nil {
+        return nil, 0, err
+    }
+
+    if err := guard.CheckFinite(guard.StageInput, t); err != nil {
+        return nil, 0, err
+    }
+
+    if !(maxNorm >= 0 && maxNorm < 1) {
+        return nil, 0, &MaxNormError{MaxNorm: maxNorm}
+    }
+
+    var svd mat.SVD
+
+    if ok := svd.Factorize(t, mat.SVDThin); !ok {
+        return nil, 0, fmt.Errorf("svd: Factorize unsuccessful %v", mat.Formatted(t, mat.Prefix("    "), mat.Squeeze()))
+    }
+
+    values := svd.Values(nil)
+
+    if values[0] <= maxNorm {
+        return mat.DenseCopyOf(t), 0, nil
+    }
+
+    for i, sigma := range values {
+        if sigma > maxNorm {
+            distance = math.Hypot(distance, sigma - maxNorm)
+            values[i] = maxNorm
+        }
+    }
+
+    var w, v mat.Dense
+    svd.UTo(&w)
+    svd.VTo(&v)
+
+    w.Apply(func(i, j int, x float64) float64 {
+        return x * values[j]
+    }, &w)
+
+    r, c := t.Dims()
+    nearest = mat.NewDense(r, c, nil)
+    nearest.Mul(&w, v.T())
+
+    return nearest, distance, nil
+}
+
+// projects t with NearestContraction before its dilation is calculated, the distance is discarded
+func WithNearestContraction(maxNorm float64) Option {
+    return func(o *options) {
+        o.project = true
+        o.maxNorm = maxNorm
+    }
+}
+
+// returns t or its nearest contraction, if WithNearestContraction was passed
+func (o *options) projected(t mat.Matrix) (mat.Matrix, error) {
+    if !o.project {
+        return t, nil
+    }
+
+    nearest, _, err := NearestContraction(t, o.maxNorm)
+
+    if err != nil {
+        return nil, err
+    }
+
+    return nearest, nil
+}
+
+// returns an *OptionError, if WithNearestContraction was passed to a function that can not project its input
+func (o *options) rejectProjection(reason string) error {
+    if o.project {
+        return &OptionError{Option: "WithNearestContraction", Reason: reason}
+    }
+
+    return nil
+}
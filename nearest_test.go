@@ -0,0 +1,182 @@
+package godilation
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "reflect"
+    "testing"
+)
+
+func TestNearestContraction(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        maxNorm float64
+        expected *mat.Dense
+        distance float64
+    }{
+        {desc: "diagonal", value: mat.NewDense(2, 2, []float64{1.2,0,0,-0.5,}), maxNorm: 0.9, expected: mat.NewDense(2, 2, []float64{0.9,0,0,-0.5,}), distance: 0.3},
+        {desc: "two clipped singular values", value: mat.NewDense(2, 2, []float64{0,2,-1.5,0,}), maxNorm: 0.5, expected: mat.NewDense(2, 2, []float64{0,0.5,-0.5,0,}), distance: math.Hypot(1.5, 1)},
+        {desc: "contraction is unchanged", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), maxNorm: 0.99, expected: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), distance: 0},
+        {desc: "rectangular", value: mat.NewDense(1, 2, []float64{3,4,}), maxNorm: 0.5, expected: mat.NewDense(1, 2, []float64{0.3,0.4,}), distance: 4.5},
+        {desc: "zero norm", value: mat.NewDense(1, 1, []float64{-2}), maxNorm: 0, expected: mat.NewDense(1, 1, []float64{0}), distance: 2},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            nearest, distance, err := NearestContraction(table.value, table.maxNorm)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if !mat.EqualApprox(nearest, table.expected, 1e-14) || math.Abs(distance - table.distance) > 1e-14 {
+                t.Errorf("Wrong projection, got: %v, %v, want: %v, %v", mat.Formatted(nearest), distance, mat.Formatted(table.expected), table.distance)
+            }
+
+            diff := mat.DenseCopyOf(nearest)
+            diff.Sub(table.value, nearest)
+
+            if math.Abs(mat.Norm(diff, 2) - distance) > 1e-14 {
+                t.Errorf("Distance %v does not match the Frobenius distance %v", distance, mat.Norm(diff, 2))
+            }
+        })
+    }
+}
+
+func TestNearestContractionReturnsCopy(t *testing.T) {
+    value := mat.NewDense(1, 1, []float64{0.5})
+    nearest, _, _ := NearestContraction(value, 0.9)
+    nearest.Set(0, 0, 0)
+
+    if value.At(0, 0) != 0.5 {
+        t.Errorf("Input was modified")
+    }
+}
+
+func TestNearestContractionErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        maxNorm float64
+        expected error
+    }{
+        {desc: "max norm 1", value: mat.NewDense(1, 1, []float64{2}), maxNorm: 1, expected: &MaxNormError{MaxNorm: 1}},
+        {desc: "negative max norm", value: mat.NewDense(1, 1, []float64{2}), maxNorm: -0.5, expected: &MaxNormError{MaxNorm: -0.5}},
+        {desc: "nil", value: nil, maxNorm: 0.5, expected: ErrNilMatrix},
+        {desc: "non-finite", value: mat.NewDense(1, 1, []float64{math.Inf(1)}), maxNorm: 0.5, expected: &NonFiniteError{Stage: StageInput, Row: 0, Col: 0, Value: math.Inf(1)}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            nearest, _, err := NearestContraction(table.value, table.maxNorm)
+
+            if !reflect.DeepEqual(err, table.expected) || nearest != nil {
+                t.Errorf("Wrong result, got: %v, %v, want: nil, %v", nearest, err, table.expected)
+            }
+        })
+    }
+
+    if _, _, err := NearestContraction(mat.NewDense(1, 1, []float64{2}), math.NaN()); fmt.Sprint(err) != "Unexpected maximal norm: NaN (Expecting a norm in [0, 1))" {
+        t.Errorf("Wrong error for NaN, got: %v", err)
+    }
+}
+
+func TestUnitaryNDilationWithNearestContraction(t *testing.T) {
+    value := mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,})
+
+    if _, err := UnitaryNDilation(value, 2); err == nil {
+        t.Fatalf("Expected the input not to be a contraction")
+    }
+
+    nearest, _, err := NearestContraction(value, 0.95)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    unitary, err := UnitaryNDilation(value, 2, WithNearestContraction(0.95))
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !mat.EqualApprox(unitary.Slice(0, 2, 0, 2), nearest, 1e-15) || unitarityDeviation(unitary) > 1e-9 {
+        t.Errorf("Wrong dilation of the nearest contraction: %v", mat.Formatted(unitary))
+    }
+
+    var dst mat.Dense
+
+    if err := UnitaryNDilationTo(&dst, value, 2, WithNearestContraction(0.95)); err != nil || !mat.Equal(&dst, unitary) {
+        t.Errorf("Wrong dilation into destination, got: %v, %v", mat.Formatted(&dst), err)
+    }
+
+    if _, err := UnitaryNDilation(value, 2, WithNearestContraction(1.5)); !reflect.DeepEqual(err, &MaxNormError{MaxNorm: 1.5}) {
+        t.Errorf("Wrong error, got: %v", err)
+    }
+}
+
+func TestWithNearestContractionEntryPoints(t *testing.T) {
+    value := mat.NewDense(2, 2, []float64{1.2,0,0,0.5,})
+    projected := mat.NewDense(2, 2, []float64{0.9,0,0,0.5,})
+    option := WithNearestContraction(0.9)
+
+    unitary, condition, err := UnitaryNDilationWithCondition(value, 2, option)
+
+    if err != nil {
+        t.Fatalf("UnitaryNDilationWithCondition returned unexpected error: %v", err)
+    }
+
+    if expected, _ := ConditionOf(projected, 2); math.Abs(condition.Norm - 0.9) > 1e-15 || math.Abs(condition.Relative - expected.Relative) > 1e-12 {
+        t.Errorf("Condition is not the one of the nearest contraction, got: %+v, want: %+v", condition, expected)
+    }
+
+    if !mat.EqualApprox(unitary.Slice(0, 2, 0, 2), projected, 1e-15) {
+        t.Errorf("Dilation is not the one of the nearest contraction: %v", mat.Formatted(unitary))
+    }
+
+    if _, rounded, err := UnitaryNDilationBigFloat(value, 2, option); err != nil || !mat.EqualApprox(rounded.Slice(0, 2, 0, 2), projected, 1e-15) {
+        t.Errorf("UnitaryNDilationBigFloat did not project, got: %v, %v", rounded, err)
+    }
+
+    if verified, err := UnitaryNDilationVerified(value, 2, option); err != nil || verified.NormBound < 0.9 || verified.NormBound >= 1 {
+        t.Errorf("UnitaryNDilationVerified did not project, got: %+v, %v", verified, err)
+    }
+
+    if embedding, err := JordanWielandtDilation(value, option); err != nil || !mat.EqualApprox(embedding.Slice(0, 2, 2, 4), projected, 1e-15) {
+        t.Errorf("JordanWielandtDilation did not project, got: %v, %v", embedding, err)
+    }
+
+    unsupported := []struct {
+        desc string
+        run func() error
+    }{
+        {desc: "UnitaryNDilationRat", run: func() error {
+            _, _, err := UnitaryNDilationRat(ratMatrix(1, 1, "1/2"), 1, option)
+            return err
+        }},
+        {desc: "UnitaryNDilationDerivative", run: func() error {
+            _, err := UnitaryNDilationDerivative(projected, projected, 1, option)
+            return err
+        }},
+        {desc: "UnitaryNDilationAdjoint", run: func() error {
+            _, err := UnitaryNDilationAdjoint(projected, mat.NewDense(4, 4, nil), 1, option)
+            return err
+        }},
+        {desc: "HalmosDilation", run: func() error {
+            _, err := HalmosDilation(projected, option)
+            return err
+        }},
+    }
+
+    for _, u := range unsupported {
+        if _, ok := u.run().(*OptionError); !ok {
+            t.Errorf("%s did not reject WithNearestContraction", u.desc)
+        }
+    }
+}
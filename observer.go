@@ -0,0 +1,78 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/sqrtm"
+    "log"
+    "time"
+)
+
+// Statistics of a stage of the calculation of a dilation
+type StageEvent struct {
+    Stage Stage
+    Duration time.Duration
+    // for StageSquareRoot the iterations, residual and stop reason of the square root, otherwise the zero value
+    SquareRoot sqrtm.Result
+    // for StagePositiveDefiniteCheck whether I - TTᵀ is positive definite, otherwise false
+    PositiveDefinite bool
+    // error of the stage, nil if it was successful
+    Err error
+}
+
+/*
+    Observer receives the progress of the calculation of a dilation.
+    StageDone is called when a stage ends, so nested stages are reported first:
+    with the ExponentialMethod backend the positive definite check and each square root are reported before StageDefects,
+    followed by StageBlockAssembly. For a normal t the defect operators come from one eigendecomposition,
+    so no positive definite check or square root is reported.
+    Iteration is called after each iteration of the Exponential Method for Matrices.
+    Both methods are called synchronously on the calculating goroutine, with WithParallelBlocks on the goroutine of each block.
+*/
+type Observer interface {
+    StageDone(StageEvent)
+    Iteration(sqrtm.Iteration)
+}
+
+// passes the observer to the calculation, the default and the replacement for nil is NopObserver
+func WithObserver(observer Observer) Option {
+    if observer == nil {
+        observer = NopObserver{}
+    }
+
+    return func(o *options) {
+        o.observer = observer
+    }
+}
+
+// Observer that ignores all events
+type NopObserver struct{}
+
+func (NopObserver) StageDone(StageEvent) {}
+
+func (NopObserver) Iteration(sqrtm.Iteration) {}
+
+// Observer that writes every event as one line to a log.Logger
+type LogObserver struct {
+    Logger *log.Logger
+}
+
+// returns a LogObserver writing to logger
+func NewLogObserver(logger *log.Logger) *LogObserver {
+    return &LogObserver{Logger: logger}
+}
+
+func (l *LogObserver) StageDone(e StageEvent) {
+    switch {
+    case e.Err != nil:
+        l.Logger.Printf("%v failed after %v: %v", e.Stage, e.Duration, e.Err)
+    case e.Stage == StageSquareRoot:
+        l.Logger.Printf("%v took %v: %d iterations, residual %e (%v)", e.Stage, e.Duration, e.SquareRoot.Iterations, e.SquareRoot.Residual, e.SquareRoot.Reason)
+    case e.Stage == StagePositiveDefiniteCheck:
+        l.Logger.Printf("%v took %v: positive definite %t", e.Stage, e.Duration, e.PositiveDefinite)
+    default:
+        l.Logger.Printf("%v took %v", e.Stage, e.Duration)
+    }
+}
+
+func (l *LogObserver) Iteration(i sqrtm.Iteration) {
+    l.Logger.Printf("iteration %d: residual %e", i.Iteration, i.Residual)
+}
@@ -0,0 +1,168 @@
+package godilation
+
+import (
+    "bytes"
+    "fmt"
+    "github.com/acra5y/go-dilation/sqrtm"
+    "gonum.org/v1/gonum/mat"
+    "log"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+)
+
+type recordingObserver struct {
+    stages []StageEvent
+    iterations []sqrtm.Iteration
+}
+
+func (r *recordingObserver) StageDone(e StageEvent) {
+    r.stages = append(r.stages, e)
+}
+
+func (r *recordingObserver) Iteration(i sqrtm.Iteration) {
+    r.iterations = append(r.iterations, i)
+}
+
+func (r *recordingObserver) stageOrder() []Stage {
+    order := make([]Stage, len(r.stages))
+
+    for i, e := range r.stages {
+        order[i] = e.Stage
+    }
+
+    return order
+}
+
+func TestObserverStages(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        backend Backend
+        expected []Stage
+    }{
+        {
+            desc: "square roots for a non symmetric matrix",
+            value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}),
+            backend: ExponentialMethod,
+            expected: []Stage{StagePositiveDefiniteCheck, StageSquareRoot, StageSquareRoot, StageDefects, StageBlockAssembly},
+        },
+        {
+            desc: "no square root for a normal matrix",
+            value: mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,}),
+            backend: ExponentialMethod,
+            expected: []Stage{StageDefects, StageBlockAssembly},
+        },
+        {
+            desc: "singular value decomposition",
+            value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}),
+            backend: SingularValueDecomposition,
+            expected: []Stage{StageDefects, StageBlockAssembly},
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            observer := &recordingObserver{}
+
+            if _, err := UnitaryNDilation(table.value, 2, WithBackend(table.backend), WithObserver(observer)); err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if got := observer.stageOrder(); !reflect.DeepEqual(got, table.expected) {
+                t.Errorf("Wrong stages, got: %v, want: %v", got, table.expected)
+            }
+
+            iterations := 0
+
+            for _, e := range observer.stages {
+                if e.Err != nil || e.Duration < 0 {
+                    t.Errorf("Unexpected event: %+v", e)
+                }
+
+                if e.Stage == StagePositiveDefiniteCheck && !e.PositiveDefinite {
+                    t.Errorf("Positive definite check not reported as positive definite: %+v", e)
+                }
+
+                if e.Stage == StageSquareRoot {
+                    if e.SquareRoot.Reason != sqrtm.Converged {
+                        t.Errorf("Square root did not converge: %+v", e.SquareRoot)
+                    }
+                    iterations += e.SquareRoot.Iterations
+                }
+            }
+
+            if len(observer.iterations) != iterations {
+                t.Errorf("Wrong number of iterations, got: %d, want: %d", len(observer.iterations), iterations)
+            }
+        })
+    }
+}
+
+func TestObserverReportsFailedStage(t *testing.T) {
+    observer := &recordingObserver{}
+    expectedErr := fmt.Errorf("Input is not a contraction")
+
+    _, err := UnitaryNDilation(mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,}), 2, WithObserver(observer))
+
+    if !reflect.DeepEqual(err, expectedErr) {
+        t.Fatalf("Wrong error, got: %v, want: %v", err, expectedErr)
+    }
+
+    expected := []Stage{StagePositiveDefiniteCheck, StageDefects}
+
+    if got := observer.stageOrder(); !reflect.DeepEqual(got, expected) {
+        t.Fatalf("Wrong stages, got: %v, want: %v", got, expected)
+    }
+
+    if observer.stages[0].PositiveDefinite || !reflect.DeepEqual(observer.stages[1].Err, expectedErr) {
+        t.Errorf("Wrong events, got: %+v", observer.stages)
+    }
+}
+
+func TestObserverWithTo(t *testing.T) {
+    observer := &recordingObserver{}
+
+    if err := UnitaryNDilationTo(&mat.Dense{}, mat.NewDense(1, 1, []float64{0.5}), 2, WithBackend(SingularValueDecomposition), WithObserver(observer)); err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := []Stage{StageDefects, StageBlockAssembly}
+
+    if got := observer.stageOrder(); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Wrong stages, got: %v, want: %v", got, expected)
+    }
+}
+
+func TestWithObserverNil(t *testing.T) {
+    if _, err := UnitaryNDilation(mat.NewDense(1, 1, []float64{0.5}), 2, WithObserver(nil)); err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
+
+func TestLogObserver(t *testing.T) {
+    var buf bytes.Buffer
+    observer := NewLogObserver(log.New(&buf, "", 0))
+
+    observer.StageDone(StageEvent{Stage: StageSquareRoot, Duration: time.Millisecond, SquareRoot: sqrtm.Result{Iterations: 7, Residual: 1e-11, Reason: sqrtm.Converged}})
+    observer.StageDone(StageEvent{Stage: StagePositiveDefiniteCheck, Duration: time.Second, PositiveDefinite: true})
+    observer.StageDone(StageEvent{Stage: StageBlockAssembly, Duration: time.Microsecond})
+    observer.StageDone(StageEvent{Stage: StageDefects, Duration: time.Second, Err: fmt.Errorf("Input is not a contraction")})
+    observer.Iteration(sqrtm.Iteration{Iteration: 3, Residual: 0.5})
+
+    expected := strings.Join([]string{
+        "square root took 1ms: 7 iterations, residual 1.000000e-11 (converged)",
+        "positive definite check took 1s: positive definite true",
+        "block assembly took 1µs",
+        "defect operators failed after 1s: Input is not a contraction",
+        "iteration 3: residual 5.000000e-01",
+        "",
+    }, "\n")
+
+    if got := buf.String(); got != expected {
+        t.Errorf("Wrong log, got: %q, want: %q", got, expected)
+    }
+}
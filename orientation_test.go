@@ -0,0 +1,170 @@
+package godilation
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/rand"
+    "testing"
+)
+
+func TestWithSpecialOrthogonal(t *testing.T) {
+    backends := []struct {
+        desc string
+        backend Backend
+        tol float64
+    }{
+        {desc: "exponential method", backend: ExponentialMethod, tol: 1e-5},
+        {desc: "singular value decomposition", backend: SingularValueDecomposition, tol: 1e-12},
+    }
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+    }{
+        {desc: "scalar", value: mat.NewDense(1, 1, []float64{0.6,}), degree: 1},
+        {desc: "scalar with degree > 1", value: mat.NewDense(1, 1, []float64{-0.6,}), degree: 4},
+        {desc: "non normal matrix", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 3},
+        {desc: "symmetric matrix", value: mat.NewSymDense(2, []float64{0.5,0.1,0.1,0.2,}), degree: 2},
+        {desc: "3x3 matrix", value: mat.NewDense(3, 3, []float64{0.1,0,0.3,0, 0.12, 0.1412,0, 0, 0.12}), degree: 1},
+    }
+
+    flipped := 0
+
+    for _, backend := range backends {
+        for _, table := range tables {
+            unitary, err := UnitaryNDilation(table.value, table.degree, WithBackend(backend.backend))
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            special, err := UnitaryNDilation(table.value, table.degree, WithBackend(backend.backend), WithSpecialOrthogonal())
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if det := mat.Det(special); math.Abs(det - 1) > 1e-8 {
+                t.Errorf("%s %s: wrong determinant, got: %v, want: 1", backend.desc, table.desc, det)
+            }
+
+            if mat.Det(unitary) < 0 {
+                flipped++
+            }
+
+            isNDilation(t, special, table.value, table.degree, backend.tol)
+
+            var dst mat.Dense
+
+            if err := UnitaryNDilationTo(&dst, table.value, table.degree, WithBackend(backend.backend), WithSpecialOrthogonal()); err != nil || !mat.Equal(&dst, special) {
+                t.Errorf("%s %s: UnitaryNDilationTo wrote wrong value, got: %v, %v, want: %v, nil", backend.desc, table.desc, &dst, err, special)
+            }
+        }
+    }
+
+    if flipped == 0 {
+        t.Errorf("No dilation with determinant -1, the orientation is not tested")
+    }
+}
+
+func TestWithSpecialOrthogonalComposedDilations(t *testing.T) {
+    value := mat.NewDense(3, 3, []float64{0.5,0,0.5, 0,0.3,0, 0,0,0.5,})
+    decomposed, _, err := UnitaryNDilationDecomposed(value, 2, WithSpecialOrthogonal())
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if det := mat.Det(decomposed); math.Abs(det - 1) > 1e-8 {
+        t.Errorf("Wrong determinant of the decomposed dilation, got: %v, want: 1", det)
+    }
+
+    isNDilation(t, decomposed, value, 2, 1e-5)
+
+    scalar := mat.NewDense(1, 1, []float64{0.6,})
+    kronecker, err := UnitaryNDilationKronecker(scalar, value, 1, WithSpecialOrthogonal())
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if det := mat.Det(kronecker); math.Abs(det - 1) > 1e-8 {
+        t.Errorf("Wrong determinant of the Kronecker dilation, got: %v, want: 1", det)
+    }
+}
+
+func TestWithSpecialOrthogonalEntryPoints(t *testing.T) {
+    // the determinant does not depend on the contraction, for t = 0 the dilation of degree 1 of a 3x3 matrix swaps three pairs of coordinates
+    value := randomContraction(3, 0.7)
+    rnd := rand.New(rand.NewSource(5))
+    opts := []Option{WithBackend(SingularValueDecomposition), WithSpecialOrthogonal()}
+
+    unitary, err := UnitaryNDilation(value, 1, WithBackend(SingularValueDecomposition))
+
+    if err != nil || mat.Det(unitary) > 0 {
+        t.Fatalf("Expected a dilation with determinant -1, got: %v, %v", unitary, err)
+    }
+
+    special, err := UnitaryNDilation(value, 1, opts...)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    e := randomMatrix(rnd, 3, 3)
+    derivative, err := UnitaryNDilationDerivative(value, e, 1, opts...)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    h := 1e-6
+    plus, minus := mat.NewDense(3, 3, nil), mat.NewDense(3, 3, nil)
+    plus.Scale(h, e)
+    minus.Sub(value, plus)
+    plus.Add(value, plus)
+    unitaryPlus, _ := UnitaryNDilation(plus, 1, opts...)
+    unitaryMinus, _ := UnitaryNDilation(minus, 1, opts...)
+    central := mat.NewDense(6, 6, nil)
+    central.Sub(unitaryPlus, unitaryMinus)
+    central.Scale(1 / (2 * h), central)
+
+    if !mat.EqualApprox(derivative, central, 1e-7) {
+        t.Errorf("Derivative is not the one of the special orthogonal dilation, got: %v, want: %v", mat.Formatted(derivative), mat.Formatted(central))
+    }
+
+    g := randomMatrix(rnd, 6, 6)
+    gradient, err := UnitaryNDilationAdjoint(value, g, 1, opts...)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    var lhs, rhs mat.Dense
+    lhs.MulElem(g, derivative)
+    rhs.MulElem(gradient, e)
+
+    if l, r := mat.Sum(&lhs), mat.Sum(&rhs); math.Abs(l - r) > 1e-9 * math.Abs(l) {
+        t.Errorf("Adjoint does not match the derivative: %v != %v", l, r)
+    }
+
+    unitaryBig, rounded, err := UnitaryNDilationBigFloat(value, 1, WithSpecialOrthogonal())
+
+    if err != nil || !mat.EqualApprox(rounded, special, 1e-12) {
+        t.Errorf("UnitaryNDilationBigFloat is not special orthogonal, got: %v, %v", rounded, err)
+    }
+
+    if _, rounded, err := UnitaryNDilationRat(ratMatrix(1, 1, "3/5"), 1, WithSpecialOrthogonal()); err != nil || math.Abs(mat.Det(rounded) - 1) > 1e-15 {
+        t.Errorf("UnitaryNDilationRat is not special orthogonal, got: %v, %v", rounded, err)
+    }
+
+    verified, err := UnitaryNDilationVerified(value, 1, WithSpecialOrthogonal())
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !enclosesBig(verified.Enclosure, unitaryBig) || !mat.EqualApprox(verified.Dilation, special, 1e-12) || verified.UnitarityBound > 1e-12 {
+        t.Errorf("UnitaryNDilationVerified is not special orthogonal, got: %+v", verified)
+    }
+}
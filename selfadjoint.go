@@ -0,0 +1,60 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+// validates an input of JordanWielandtDilation or HalmosDilation, which have no degree
+func validateMatrix(t mat.Matrix) error {
+    if err := guard.CheckMatrix(t); err != nil {
+        return err
+    }
+
+    return guard.CheckFinite(guard.StageInput, t)
+}
+
+/*
+    Returns the symmetric Jordan–Wielandt dilation [[0, T], [Tᵀ, 0]] of the m times n matrix t, which has dimension m + n
+    and the eigenvalues ±σ_i of the singular values of t. t does not need to be a contraction, with WithNearestContraction it is projected anyway.
+    The result is checked to be symmetric, a *VerificationError with StageStructureCheck is returned otherwise.
+*/
+func JordanWielandtDilation(t mat.Matrix, opts ...Option) (dilated *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validateMatrix(t); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return nil, err
+    }
+
+    return dilation.JordanWielandtDilation(o.newBlockMatrix, t)
+}
+
+/*
+    Returns the Halmos dilation [[T, D], [D, -T]] of the symmetric contraction t with D = sqrt(I - T²), which is symmetric and orthogonal,
+    so it is a reflection: its square is the identity. t must be exactly symmetric, e.g. a *mat.SymDense.
+    The result is checked to be symmetric, orthogonal and involutive up to 1e-10 per dimension, a *VerificationError with StageStructureCheck is returned otherwise.
+    With the ExponentialMethod backend D is calculated by an eigendecomposition instead, as the Exponential Method may converge to another square root.
+    WithNearestContraction results in an *OptionError, as the projection does not keep t exactly symmetric.
+*/
+func HalmosDilation(t mat.Matrix, opts ...Option) (reflection *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    if err := validateMatrix(t); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if err := o.rejectProjection("the projection does not keep t exactly symmetric"); err != nil {
+        return nil, err
+    }
+
+    return dilation.HalmosDilation(o.defectsWith(o.symmetricSquareRoot), o.newBlockMatrix, t)
+}
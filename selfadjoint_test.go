@@ -0,0 +1,132 @@
+package godilation
+
+import (
+    "errors"
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "reflect"
+    "sort"
+    "testing"
+)
+
+func TestJordanWielandtDilation(t *testing.T) {
+    value := mat.NewDense(2, 3, []float64{3,0,0,0,0,-2,})
+    u, err := JordanWielandtDilation(value)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    expected := mat.NewDense(5, 5, []float64{
+        0,0,3,0,0,
+        0,0,0,0,-2,
+        3,0,0,0,0,
+        0,0,0,0,0,
+        0,-2,0,0,0,
+    })
+
+    if !mat.Equal(u, expected) {
+        t.Errorf("Wrong dilation, got: %v, want: %v", u, expected)
+    }
+
+    var eigen mat.EigenSym
+
+    if ok := eigen.Factorize(mat.NewSymDense(5, u.RawMatrix().Data), false); !ok {
+        t.Fatalf("Eigendecomposition failed")
+    }
+
+    values := eigen.Values(nil)
+    sort.Float64s(values)
+    expectedValues := []float64{-3, -2, 0, 2, 3}
+
+    for i := range values {
+        if math.Abs(values[i] - expectedValues[i]) > 1e-14 {
+            t.Errorf("Eigenvalues are not ±σ_i, got: %v, want: %v", values, expectedValues)
+            break
+        }
+    }
+}
+
+func TestHalmosDilation(t *testing.T) {
+    backends := []struct {
+        desc string
+        backend Backend
+    }{
+        {desc: "exponential method", backend: ExponentialMethod},
+        {desc: "singular value decomposition", backend: SingularValueDecomposition},
+    }
+    tables := []struct {
+        desc string
+        value mat.Matrix
+    }{
+        {desc: "diagonal matrix", value: mat.NewDiagDense(2, []float64{0.5,-0.2,})},
+        {desc: "symmetric matrix", value: mat.NewSymDense(3, []float64{0.3,0.1,0.2, 0.1,0.4,0, 0.2,0,-0.5,})},
+        {desc: "symmetric dense matrix", value: mat.NewDense(2, 2, []float64{0.5,0.4,0.4,0.1,})},
+    }
+
+    for _, backend := range backends {
+        for _, table := range tables {
+            backend, table := backend, table
+            t.Run(backend.desc + " " + table.desc, func(t *testing.T) {
+                t.Parallel()
+                u, err := HalmosDilation(table.value, WithBackend(backend.backend))
+
+                if err != nil {
+                    t.Fatalf("Unexpected error: %v", err)
+                }
+
+                n, _ := table.value.Dims()
+
+                if r, c := u.Dims(); r != 2 * n || c != 2 * n {
+                    t.Fatalf("Wrong dimension, got: (%d, %d), want: (%d, %d)", r, c, 2 * n, 2 * n)
+                }
+
+                if !mat.Equal(u, u.T()) {
+                    t.Errorf("Dilation is not symmetric: %v", u)
+                }
+
+                if deviation := unitarityDeviation(u); deviation > 1e-12 {
+                    t.Errorf("Dilation is not orthogonal, ‖UᵀU − I‖_F = %v", deviation)
+                }
+
+                if !mat.Equal(u.Slice(0, n, 0, n), table.value) {
+                    t.Errorf("Wrong upper left block, got: %v, want: %v", u.Slice(0, n, 0, n), table.value)
+                }
+            })
+        }
+    }
+}
+
+func TestSelfAdjointDilationErrors(t *testing.T) {
+    var nilDense *mat.Dense
+
+    tables := []struct {
+        desc string
+        run func() (*mat.Dense, error)
+        expected error
+    }{
+        {desc: "Jordan–Wielandt dilation of nil", run: func() (*mat.Dense, error) { return JordanWielandtDilation(nilDense) }, expected: ErrNilMatrix},
+        {desc: "Jordan–Wielandt dilation of Inf", run: func() (*mat.Dense, error) { return JordanWielandtDilation(mat.NewDense(1, 1, []float64{math.Inf(1),})) }, expected: &NonFiniteError{Stage: StageInput, Row: 0, Col: 0, Value: math.Inf(1)}},
+        {desc: "Halmos dilation of nil", run: func() (*mat.Dense, error) { return HalmosDilation(nil) }, expected: ErrNilMatrix},
+        {desc: "Halmos dilation of a rectangular matrix", run: func() (*mat.Dense, error) { return HalmosDilation(mat.NewDense(1, 2, nil)) }, expected: fmt.Errorf("Matrix does not have square dimension")},
+        {desc: "Halmos dilation of an asymmetric matrix", run: func() (*mat.Dense, error) { return HalmosDilation(mat.NewDense(2, 2, []float64{0.5,0.1,0,0.5,})) }, expected: fmt.Errorf("Matrix is not symmetric")},
+        {desc: "Halmos dilation of a non contraction", run: func() (*mat.Dense, error) { return HalmosDilation(mat.NewSymDense(2, []float64{1,0,0,0.5,})) }, expected: fmt.Errorf("Input is not a contraction")},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            u, err := table.run()
+
+            if u != nil {
+                t.Errorf("Unexpected result: %v", u)
+            }
+
+            if !(errors.Is(err, table.expected) || reflect.DeepEqual(err, table.expected)) {
+                t.Errorf("Wrong error, got: %v, want: %v", err, table.expected)
+            }
+        })
+    }
+}
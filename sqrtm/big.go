@@ -0,0 +1,98 @@
+package sqrtm
+
+import (
+    "github.com/acra5y/go-dilation/bigmat"
+    "math/big"
+)
+
+/*
+    The square root in big.Float precision is calculated with the Denman–Beavers iteration
+        Y_0 = A, Z_0 = I, Y_{k+1} = (Y_k + Z_k^{-1}) / 2, Z_{k+1} = (Z_k + Y_k^{-1}) / 2,
+    where Y_k converges quadratically to sqrt(A) and Z_k to sqrt(A)^{-1} for an A without eigenvalues on the closed negative real axis
+    (N. J. Higham: Functions of Matrices, chapter 6.3). Unlike the Exponential Method, the iteration does not have to stop
+    before its iterates become ill-conditioned, so it reaches the precision of the calculation for ill-conditioned inputs as well.
+    Once the relative step ‖Y_{k+1} − Y_k‖_F / ‖Y_{k+1}‖_F falls below 2^(−prec/2), one more iteration doubles the number of correct bits.
+*/
+
+func denmanBeaversStep(y, z *bigmat.Dense, half *big.Float) (nextY, nextZ *bigmat.Dense, err error) {
+    yInverse, err := bigmat.Inverse(y)
+
+    if err != nil {
+        return nil, nil, err
+    }
+
+    zInverse, err := bigmat.Inverse(z)
+
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return bigmat.Scale(half, bigmat.Add(y, zInverse)), bigmat.Scale(half, bigmat.Add(z, yInverse)), nil
+}
+
+// returns ‖Q² − A‖_F / ‖A‖_F or the absolute residual if A is zero, rounded to float64
+func bigResidual(a, q *bigmat.Dense) float64 {
+    norm := bigmat.Sub(bigmat.Mul(q, q), a).Norm()
+
+    if aNorm := a.Norm(); aNorm.Sign() != 0 {
+        norm.Quo(norm, aNorm)
+    }
+
+    r, _ := norm.Float64()
+    return r
+}
+
+/*
+    Returns the principal square root of the square matrix a in the precision of a together with the statistics of the calculation.
+    Only MaxIterations of settings is used, a nil settings selects the default. The error is ErrNotSquare,
+    bigmat.ErrSingular if an iterate is singular in the precision of a, e.g. for a singular a,
+    or a *NotConvergedError if the iteration did not converge within MaxIterations.
+*/
+func SqrtBig(a *bigmat.Dense, settings *Settings) (*bigmat.Dense, Result, error) {
+    s := settings.withDefaults()
+    m, n := a.Dims()
+
+    if m != n {
+        return nil, Result{}, ErrNotSquare
+    }
+
+    prec := a.Prec()
+    half := new(big.Float).SetPrec(prec).SetFloat64(0.5)
+    threshold := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), -int(prec / 2))
+    step := new(big.Float).SetPrec(prec)
+    y, z := a.Clone(), bigmat.Identity(n, prec)
+    result := Result{Reason: MaxIterationsReached}
+    converging := false
+
+    for i := 1; i <= s.MaxIterations; i++ {
+        nextY, nextZ, err := denmanBeaversStep(y, z, half)
+
+        if err != nil {
+            result.Iterations = i
+            return nil, result, err
+        }
+
+        step.Quo(bigmat.Sub(nextY, y).Norm(), nextY.Norm())
+        y, z = nextY, nextZ
+        result.Iterations = i
+
+        if s.OnIteration != nil {
+            s.OnIteration(Iteration{Iteration: i, Residual: bigResidual(a, y)})
+        }
+
+        if converging {
+            result.Reason = Converged
+            break
+        }
+
+        converging = step.Cmp(threshold) <= 0
+    }
+
+    result.Residual = bigResidual(a, y)
+
+    if result.Reason != Converged {
+        return y, result, &NotConvergedError{Result: result}
+    }
+
+    return y, result, nil
+}
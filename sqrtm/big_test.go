@@ -0,0 +1,91 @@
+package sqrtm
+
+import (
+    "github.com/acra5y/go-dilation/bigmat"
+    "gonum.org/v1/gonum/mat"
+    "testing"
+)
+
+func TestSqrtBig(t *testing.T) {
+    illConditioned, _ := withEigenvalues(logSpaced(6, 1e-14, 1), 1)
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        maxResidual float64
+    }{
+        {desc: "diagonal", value: mat.NewDense(2, 2, []float64{4, 0, 0, 9}), maxResidual: 1e-70},
+        {desc: "symmetric", value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), maxResidual: 1e-70},
+        {desc: "non symmetric", value: mat.NewDense(2, 2, []float64{0.75,-0.25,0,0.5,}), maxResidual: 1e-70},
+        {desc: "ill-conditioned", value: illConditioned, maxResidual: 1e-60},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            a := bigmat.NewFromMatrix(table.value, 256)
+            sq, result, err := SqrtBig(a, nil)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if result.Reason != Converged || !(result.Residual <= table.maxResidual) {
+                t.Errorf("Wrong result, got: %+v", result)
+            }
+
+            if sq.Prec() != 256 {
+                t.Errorf("Wrong precision, got: %d", sq.Prec())
+            }
+        })
+    }
+}
+
+func TestSqrtBigAgreesWithFloat64(t *testing.T) {
+    value, _ := withEigenvalues([]float64{1e-6, 0.01, 0.5, 1}, 2)
+    sq, _, err := Sqrt(value, nil)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    bigSq, _, err := SqrtBig(bigmat.NewFromMatrix(value, 128), nil)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if !mat.EqualApprox(sq, bigSq.Float64(), 1e-9) {
+        t.Errorf("Square roots differ, got: %v, want: %v", mat.Formatted(bigSq.Float64()), mat.Formatted(sq))
+    }
+}
+
+func TestSqrtBigErrors(t *testing.T) {
+    if _, _, err := SqrtBig(bigmat.NewDense(2, 3, 64), nil); err != ErrNotSquare {
+        t.Errorf("Wrong error, got: %v, want: %v", err, ErrNotSquare)
+    }
+
+    if _, _, err := SqrtBig(bigmat.NewDense(2, 2, 64), nil); err != bigmat.ErrSingular {
+        t.Errorf("Wrong error, got: %v, want: %v", err, bigmat.ErrSingular)
+    }
+
+    a := bigmat.NewFromMatrix(mat.NewDense(2, 2, []float64{1e-20, 0, 0, 1}), 256)
+    _, result, err := SqrtBig(a, &Settings{MaxIterations: 2})
+
+    if _, ok := err.(*NotConvergedError); !ok || result.Iterations != 2 || result.Reason != MaxIterationsReached {
+        t.Errorf("Wrong error, got: %v, %+v", err, result)
+    }
+
+    iterations := 0
+    SqrtBig(bigmat.NewFromMatrix(mat.NewDense(1, 1, []float64{2}), 64), &Settings{OnIteration: func(i Iteration) {
+        iterations++
+
+        if i.Iteration != iterations {
+            t.Errorf("Wrong iteration, got: %d, want: %d", i.Iteration, iterations)
+        }
+    }})
+
+    if iterations == 0 {
+        t.Errorf("OnIteration was not called")
+    }
+}
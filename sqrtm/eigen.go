@@ -0,0 +1,51 @@
+package sqrtm
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+/*
+    A symmetric matrix has the eigendecomposition A = VΛVᵀ with an orthogonal V,
+    so its positive semidefinite square root is V sqrt(Λ) Vᵀ = (V Λ^(1/4))(V Λ^(1/4))ᵀ, where the roots of Λ are taken entrywise.
+    Eigenvalues that are negative within the tolerance are rounding errors of zero eigenvalues and are set to 0.
+*/
+func eigenSqrt(a mat.Symmetric, s Settings) (*mat.SymDense, Result, error) {
+    var eigen mat.EigenSym
+
+    if ok := eigen.Factorize(a, true); !ok {
+        return nil, Result{}, fmt.Errorf("eigen: Factorize unsuccessful %v", mat.Formatted(a, mat.Prefix("    "), mat.Squeeze()))
+    }
+
+    values := eigen.Values(nil)
+    complexValues := make([]complex128, len(values))
+
+    for i, value := range values {
+        complexValues[i] = complex(value, 0)
+    }
+
+    if err := checkEigenvalues(complexValues, s.EigenvalueTolerance); err != nil {
+        return nil, Result{}, err
+    }
+
+    var vectors mat.Dense
+    eigen.VectorsTo(&vectors)
+    n := len(values)
+
+    scaled := mat.NewDense(n, n, nil)
+    scaled.Apply(func(i, j int, v float64) float64 {
+        return v * math.Sqrt(math.Sqrt(math.Max(values[j], 0)))
+    }, &vectors)
+
+    sq := mat.NewSymDense(n, nil)
+    sq.SymOuterK(1, scaled)
+
+    result := Result{Reason: Converged, Residual: residual(a, mat.DenseCopyOf(sq))}
+
+    if !(result.Residual <= s.Tolerance) {
+        return sq, result, &NotConvergedError{Result: result}
+    }
+
+    return sq, result, nil
+}
@@ -0,0 +1,42 @@
+package sqrtm
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "testing"
+)
+
+func TestEigenSqrt(t *testing.T) {
+    illConditioned, expected := withEigenvalues(logSpaced(12, 1e-14, 1), 3)
+    tables := []struct {
+        desc string
+        value mat.Symmetric
+        expected mat.Matrix
+    }{
+        {desc: "eye matrix", value: mat.NewSymDense(2, []float64{1,0,0,1}), expected: mat.NewDense(2, 2, []float64{1,0,0,1})},
+        {desc: "diagonal matrix", value: mat.NewDiagDense(2, []float64{4,9}), expected: mat.NewDense(2, 2, []float64{2,0,0,3})},
+        {desc: "singular matrix", value: mat.NewSymDense(2, []float64{1,1,1,1}), expected: mat.NewDense(2, 2, []float64{math.Sqrt2 / 2,math.Sqrt2 / 2,math.Sqrt2 / 2,math.Sqrt2 / 2})},
+        {desc: "eigenvalues from 1e-14 to 1", value: mat.NewSymDense(12, illConditioned.RawMatrix().Data), expected: expected},
+        {desc: "zero eigenvalue with rounding error", value: mat.NewSymDense(2, []float64{1,0,0,-1e-16}), expected: mat.NewDense(2, 2, []float64{1,0,0,0})},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            sq, result, err := eigenSqrt(table.value, (*Settings)(nil).withDefaults())
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if result.Iterations != 0 || result.Reason != Converged {
+                t.Errorf("Wrong result: %+v", result)
+            }
+
+            if !mat.EqualApprox(sq, table.expected, 1e-9) {
+                t.Errorf("Wrong square root, got: %v, want: %v", mat.Formatted(sq), mat.Formatted(table.expected))
+            }
+        })
+    }
+}
@@ -0,0 +1,140 @@
+package sqrtm
+
+import (
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/internal/structured"
+    "gonum.org/v1/gonum/mat"
+    "math"
+)
+
+/*
+    The algorithm to calculate the square root of a positive definite matrix is taken from
+    "A New Algorithm for Computing the Square Rootof a Matrix"
+    (https://scholarworks.rit.edu/cgi/viewcontent.cgi?article=10419&context=theses, chapter three):
+    1. Declare some nonsingular matrix C with dimensions (n, n).
+    2. Initialize i for number of iterations, S_0 = I and S_1 = C.
+    3. Initialize Z = C − I.
+    4. For i iterations or until S_i becomes too ill-conditioned, do S_{i+1} = 2S_i + (Z)(S_{i−1}),
+    5. After iteration steps stop, find S_{i}^{−1}
+    .
+    6. Set n × n matrix Q = S_{i+1}(S_{i}^{−1}) − I.
+
+    The step 6 is implemented by solving a linear equation to achieve a better numerical stability:
+    For a matrix A we denote t(A) as the transposed Matrix
+    Using the notation from above, we define Q' := Q - I, R := S_{i}, S := S_{i+1}
+    We have the following equivalency: Q' = S*R^{-1} <=> Q'*R = S <=> t(R)*t(Q') = t(S).
+    This is a linear equation that we can solve without computing a matrix.
+    We assume that the matrix c fulfilles all necessary preconditions.
+*/
+
+func nextGuess(c mat.Matrix, z, prePredecessor, predecessor *mat.Dense) (guess *mat.Dense) {
+    n, _ := c.Dims()
+    var p *mat.Dense
+    guess = mat.NewDense(n, n, nil)
+    p = mat.NewDense(n, n, nil)
+    guess.Scale(2, predecessor)
+    p.Product(z, prePredecessor)
+    guess.Add(guess, p)
+    return
+}
+
+/*
+    The iterates S_i are inverted in step 6, so the iteration has to stop before S_i becomes numerically singular.
+    The condition number is estimated in the 1-norm, which neither overflows nor underflows for large dimensions like a determinant does
+    and does not depend on the sign of the determinant. A singular iterate has an infinite condition number.
+*/
+func isIllConditioned(m *mat.Dense) bool {
+    return !(mat.Cond(m, 1) <= maxCondition)
+}
+
+const (
+    // number of iterations without a new minimal residual after which the iteration is considered stagnated
+    stagnationLimit = 10
+    // condition number of an iterate above which it is considered ill-conditioned
+    maxCondition = 1e15
+)
+
+// Q = S_{i+1}(S_{i}^{−1}) − I, see step 6 above
+func candidate(eyeN mat.Matrix, predecessor, guess *mat.Dense) *mat.Dense {
+    n, _ := eyeN.Dims()
+    q := mat.NewDense(n, n, nil)
+    q.Solve(predecessor.T(), guess.T())
+    q.Sub(q.T(), eyeN)
+    return q
+}
+
+/*
+    Runs the recurrence until the relative residual of the square root is at most tolerance,
+    the iterates become ill-conditioned, the residual stagnates or maxIterations is reached.
+    A non-nil onIteration is called after each iteration.
+    Returns the square root with the smallest residual of all iterations together with the statistics of the run,
+    and an error, if the residual is larger than tolerance or no iteration produced a finite square root.
+*/
+func exponential(c mat.Matrix, tolerance float64, maxIterations int, onIteration func(Iteration)) (*mat.Dense, Result, error) {
+    n, _ := c.Dims()
+    var sq, m2, m3, z, best *mat.Dense
+    eyeN := structured.Identity(n)
+    sq = mat.NewDense(n, n, nil)
+    m2 = mat.NewDense(n, n, nil)
+    sq.Copy(eyeN)
+    m2.CloneFrom(c)
+    z = mat.NewDense(n, n, nil)
+    z.Sub(c, eyeN)
+
+    result := Result{Residual: math.Inf(1), Reason: MaxIterationsReached}
+    sinceBest := 0
+
+    for i := 1; i <= maxIterations; i++ {
+        m3 = nextGuess(c, z, sq, m2)
+        sq.CloneFrom(m2)
+        m2.CloneFrom(m3)
+        result.Iterations = i
+
+        q := candidate(eyeN, sq, m2)
+
+        r := residual(c, q)
+
+        if r < result.Residual {
+            best = q
+            result.Residual = r
+            sinceBest = 0
+        } else {
+            sinceBest++
+        }
+
+        if onIteration != nil {
+            onIteration(Iteration{Iteration: i, Residual: r})
+        }
+
+        if result.Residual <= tolerance {
+            result.Reason = Converged
+            break
+        }
+
+        if isIllConditioned(m3) {
+            result.Reason = IllConditioned
+            break
+        }
+
+        if sinceBest >= stagnationLimit {
+            result.Reason = Stagnated
+            break
+        }
+    }
+
+    if best == nil {
+        best = candidate(eyeN, sq, m2)
+        result.Residual = residual(c, best)
+    }
+
+    // only happens if no candidate was finite, as a candidate with a NaN residual is never the best one
+    if err := guard.CheckFinite(guard.StageSolve, best); err != nil {
+        return nil, result, err
+    }
+
+    if !(result.Residual <= tolerance) {
+        return best, result, &NotConvergedError{Result: result}
+    }
+
+    return best, result, nil
+}
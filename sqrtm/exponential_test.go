@@ -0,0 +1,191 @@
+package sqrtm
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "testing"
+)
+
+var dummyMatrix = mat.NewDense(2, 2, nil)
+
+func TestExponential(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+    }{
+        {value: mat.NewDense(2, 2, []float64{1,0,0,1,}), desc: "for eye matrix"},
+        {value: mat.NewDense(3,3, []float64{0.9879, 0.0011, 0.0132, 0.0011, 0.9598, 0, 0.0132, 0, 0.9712}), desc:"for a random non diagonal p.d. matrix"},
+        {value: mat.NewDense(3,3, []float64{0.1,0,0.3,0, 0.12, 0.1412,0, 0, 0.12}), desc:"for a random non diagonal p.d. matrix 2"},
+        {value: mat.NewDense(3,3, []float64{2, -1, 0, -1, 2, -1,0, -1, 2}), desc:"for a random non diagonal p.d. matrix 3"},
+        {value: mat.NewSymDense(3, []float64{2, -1, 0, -1, 2, -1,0, -1, 2}), desc:"for a symmetric matrix"},
+        {value: mat.NewDense(4, 4, []float64{2, -1, 0, 9, -1, 2, -1, 9, 0, -1, 2, 9, 9, 9, 9, 9}).Slice(0, 3, 0, 3), desc:"for a view"},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            res, _, err := exponential(table.value, DefaultTolerance, DefaultMaxIterations, nil)
+
+            if err != nil {
+                t.Errorf("Error: %v.", err)
+            }
+
+            n, _ := res.Dims()
+            value := mat.NewDense(n, n, nil)
+            value.Pow(res, 2)
+            diff := mat.NewDense(n, n, nil)
+            diff.Sub(table.value, value)
+            norm := mat.Norm(diff, 2)
+
+            if norm >= 1e-3 {
+                t.Errorf("Wrong result, got: %v, want: %v, norm difference %e", value, table.value, norm)
+            }
+        })
+    }
+}
+
+func TestExponentialWithResult(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        tolerance float64
+        maxIterations int
+        expectedReason StopReason
+        expectErr bool
+    }{
+        {value: mat.NewDense(2, 2, []float64{1,0,0,1,}), tolerance: DefaultTolerance, maxIterations: DefaultMaxIterations, expectedReason: Converged, expectErr: false, desc: "converges for eye matrix"},
+        {value: mat.NewDense(2, 2, []float64{0.75,0,0,0.96,}), tolerance: DefaultTolerance, maxIterations: DefaultMaxIterations, expectedReason: Converged, expectErr: false, desc: "converges for a diagonal matrix"},
+        {value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), tolerance: DefaultTolerance, maxIterations: DefaultMaxIterations, expectedReason: Converged, expectErr: false, desc: "converges for a non diagonal matrix"},
+        {value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), tolerance: DefaultTolerance, maxIterations: 3, expectedReason: MaxIterationsReached, expectErr: true, desc: "stops after maximum number of iterations"},
+        {value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), tolerance: 1e-20, maxIterations: DefaultMaxIterations, expectedReason: Stagnated, expectErr: true, desc: "stops if the residual stagnates"},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            res, result, err := exponential(table.value, table.tolerance, table.maxIterations, nil)
+
+            if (err != nil) != table.expectErr {
+                t.Errorf("Unexpected error, got: %v, expecting error: %t", err, table.expectErr)
+            }
+
+            if result.Reason != table.expectedReason {
+                t.Errorf("Wrong reason, got: %v, want: %v", result.Reason, table.expectedReason)
+            }
+
+            if result.Iterations < 1 || result.Iterations > table.maxIterations {
+                t.Errorf("Wrong number of iterations: %d", result.Iterations)
+            }
+
+            n, _ := res.Dims()
+            value := mat.NewDense(n, n, nil)
+            value.Mul(res, res)
+            value.Sub(value, table.value)
+
+            if r := mat.Norm(value, 2) / mat.Norm(table.value, 2); r != result.Residual {
+                t.Errorf("Wrong residual, got: %e, want: %e", result.Residual, r)
+            }
+        })
+    }
+}
+
+func TestExponentialStopsWhenConverged(t *testing.T) {
+    _, result, err := exponential(mat.NewDense(2, 2, []float64{0.75,0,0,0.96,}), 1e-6, DefaultMaxIterations, nil)
+
+    if err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+
+    if result.Residual > 1e-6 {
+        t.Errorf("Residual above tolerance: %e", result.Residual)
+    }
+
+    // the residual decreases by a factor of about 10 per iteration for this matrix
+    if result.Iterations > 10 {
+        t.Errorf("Iteration did not stop after convergence, took %d iterations", result.Iterations)
+    }
+}
+
+func TestIsIllConditioned(t *testing.T) {
+    scaledEye := func(n int, value float64) *mat.Dense {
+        m := mat.NewDense(n, n, nil)
+        for i := 0; i < n; i++ {
+            m.Set(i, i, value)
+        }
+        return m
+    }
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        expected bool
+    }{
+        {value: scaledEye(50, 1), expected: false, desc: "eye matrix"},
+        {value: scaledEye(1000, 0.1), expected: false, desc: "small entries where the determinant underflows"},
+        {value: scaledEye(1000, 10), expected: false, desc: "large entries where the determinant overflows"},
+        {value: mat.NewDense(2, 2, []float64{0,1,1,0}), expected: false, desc: "well-conditioned matrix with negative determinant"},
+        {value: mat.NewDense(2, 2, []float64{1,0,0,-1e-16}), expected: true, desc: "ill-conditioned matrix with negative determinant"},
+        {value: mat.NewDense(2, 2, []float64{1,1,1,1}), expected: true, desc: "singular matrix"},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            if got := isIllConditioned(table.value); got != table.expected {
+                t.Errorf("Wrong result, got: %t, want: %t", got, table.expected)
+            }
+        })
+    }
+}
+
+/*
+    For an eigenvalue λ of C the recurrence acts on the scalars s_0 = 1, s_1 = λ, s_{i+1} = 2s_i + (λ − 1)s_{i−1}.
+    With eigenvalues 1 and about 0 we get s_i(1) = 2^{i−1} and s_i(0) = 1 − i, so the condition number of S_i is about 2^{i−1} / (i − 1).
+    It exceeds 1e15 at i ≈ 56, independently of the dimension.
+*/
+func TestExponentialLargeDimensions(t *testing.T) {
+    for _, n := range []int{50, 200, 1000} {
+        n := n
+        t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+            if n > 200 && testing.Short() {
+                t.Skip("skipping large dimension in short mode")
+            }
+            t.Parallel()
+
+            wellConditioned := mat.NewDense(n, n, nil)
+            illConditioned := mat.NewDense(n, n, nil)
+            for i := 0; i < n; i++ {
+                wellConditioned.Set(i, i, 0.9)
+                if i > 0 {
+                    wellConditioned.Set(i, i - 1, 0.04)
+                    wellConditioned.Set(i - 1, i, 0.04)
+                }
+                illConditioned.Set(i, i, math.Pow(10, -12 * float64(i) / float64(n - 1)))
+            }
+
+            _, result, err := exponential(wellConditioned, DefaultTolerance, DefaultMaxIterations, nil)
+
+            if err != nil || result.Reason != Converged {
+                t.Errorf("Well-conditioned matrix did not converge: %v, %+v", err, result)
+            }
+
+            // eigenvalues are in [0.82, 0.98], so the error decreases by a factor of at least 20 per iteration
+            if result.Iterations > 10 {
+                t.Errorf("Well-conditioned matrix took too many iterations: %+v", result)
+            }
+
+            _, result, err = exponential(illConditioned, DefaultTolerance, DefaultMaxIterations, nil)
+
+            if err == nil || result.Reason != IllConditioned {
+                t.Errorf("Ill-conditioned matrix was not detected: %v, %+v", err, result)
+            }
+
+            if result.Iterations < 50 || result.Iterations > 60 {
+                t.Errorf("Ill-conditioned matrix stopped at the wrong iteration: %+v", result)
+            }
+        })
+    }
+}
@@ -0,0 +1,63 @@
+package sqrtm
+
+import (
+    "errors"
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+)
+
+// returned by Frechet for a square root with a zero eigenvalue, at which the square root is not differentiable
+var ErrSingular = errors.New("Square root is singular")
+
+/*
+    Returns the Fréchet derivative of the square root at A = sq² in direction e, which is the solution X of the Sylvester equation sq X + X sq = e,
+    for a symmetric positive definite square root sq. With the eigendecomposition sq = VΛVᵀ, the equation becomes (λ_i + λ_j) (VᵀXV)_ij = (VᵀeV)_ij.
+    The Sylvester operator is self-adjoint with respect to the Frobenius inner product, so Frechet is its own adjoint:
+    ⟨g, Frechet(sq, e)⟩ = ⟨Frechet(sq, g), e⟩.
+    The error is ErrNilMatrix, ErrEmptyMatrix, a *NonFiniteError, ErrNotSquare if e does not have the dimension of sq, or ErrSingular.
+*/
+func Frechet(sq mat.Symmetric, e mat.Matrix) (derivative *mat.Dense, err error) {
+    defer guard.Recover(&err)
+
+    for _, m := range []mat.Matrix{sq, e} {
+        if err := guard.CheckMatrix(m); err != nil {
+            return nil, err
+        }
+
+        if err := guard.CheckFinite(guard.StageInput, m); err != nil {
+            return nil, err
+        }
+    }
+
+    n := sq.Symmetric()
+
+    if r, c := e.Dims(); r != n || c != n {
+        return nil, ErrNotSquare
+    }
+
+    var eigen mat.EigenSym
+
+    if ok := eigen.Factorize(sq, true); !ok {
+        return nil, fmt.Errorf("eigen: Factorize unsuccessful %v", mat.Formatted(sq, mat.Prefix("    "), mat.Squeeze()))
+    }
+
+    values := eigen.Values(nil)
+
+    // the eigenvalues are in ascending order
+    if !(values[0] > 0) {
+        return nil, ErrSingular
+    }
+
+    var vectors mat.Dense
+    eigen.VectorsTo(&vectors)
+
+    derivative = mat.NewDense(n, n, nil)
+    derivative.Product(vectors.T(), e, &vectors)
+    derivative.Apply(func(i, j int, v float64) float64 {
+        return v / (values[i] + values[j])
+    }, derivative)
+    derivative.Product(&vectors, derivative, vectors.T())
+
+    return derivative, nil
+}
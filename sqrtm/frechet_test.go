@@ -0,0 +1,114 @@
+package sqrtm
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func symmetricSqrt(t *testing.T, a mat.Symmetric) *mat.SymDense {
+    sq, _, err := SqrtSym(a, &Settings{Algorithm: Eigen, Tolerance: 1e-14})
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    return sq
+}
+
+func TestFrechetFiniteDifferences(t *testing.T) {
+    c, _ := withEigenvalues([]float64{0.1, 0.5, 0.9, 2}, 3)
+    a := mat.NewSymDense(4, mat.DenseCopyOf(c).RawMatrix().Data)
+    rnd := rand.New(rand.NewSource(5))
+    e := mat.NewSymDense(4, nil)
+
+    for i := 0; i < 4; i++ {
+        for j := i; j < 4; j++ {
+            e.SetSym(i, j, rnd.NormFloat64())
+        }
+    }
+
+    derivative, err := Frechet(symmetricSqrt(t, a), e)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    h := 1e-6
+    plus, minus := mat.NewSymDense(4, nil), mat.NewSymDense(4, nil)
+
+    for i := 0; i < 4; i++ {
+        for j := i; j < 4; j++ {
+            plus.SetSym(i, j, a.At(i, j) + h * e.At(i, j))
+            minus.SetSym(i, j, a.At(i, j) - h * e.At(i, j))
+        }
+    }
+
+    central := mat.NewDense(4, 4, nil)
+    central.Sub(symmetricSqrt(t, plus), symmetricSqrt(t, minus))
+    central.Scale(1 / (2 * h), central)
+
+    if !mat.EqualApprox(derivative, central, 1e-8) {
+        t.Errorf("Derivative does not match central differences, got: %v, want: %v", mat.Formatted(derivative), mat.Formatted(central))
+    }
+}
+
+func TestFrechetIsSelfAdjoint(t *testing.T) {
+    c, _ := withEigenvalues([]float64{0.2, 0.4, 1}, 11)
+    sq := symmetricSqrt(t, mat.NewSymDense(3, mat.DenseCopyOf(c).RawMatrix().Data))
+    rnd := rand.New(rand.NewSource(13))
+    random := func() *mat.Dense {
+        d := mat.NewDense(3, 3, nil)
+        d.Apply(func(i, j int, v float64) float64 { return rnd.NormFloat64() }, d)
+        return d
+    }
+    e, g := random(), random()
+
+    derivative, err := Frechet(sq, e)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    adjoint, err := Frechet(sq, g)
+
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    if lhs, rhs := mat.Sum(mulElem(g, derivative)), mat.Sum(mulElem(adjoint, e)); math.Abs(lhs - rhs) > 1e-12 {
+        t.Errorf("Not self-adjoint: %v != %v", lhs, rhs)
+    }
+}
+
+func mulElem(a, b mat.Matrix) *mat.Dense {
+    var p mat.Dense
+    p.MulElem(a, b)
+    return &p
+}
+
+func TestFrechetErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        sq mat.Symmetric
+        e mat.Matrix
+        expected error
+    }{
+        {desc: "singular", sq: mat.NewSymDense(2, []float64{1, 0, 0, 0}), e: mat.NewDense(2, 2, nil), expected: ErrSingular},
+        {desc: "wrong dimension", sq: mat.NewSymDense(2, []float64{1, 0, 0, 1}), e: mat.NewDense(3, 3, nil), expected: ErrNotSquare},
+        {desc: "nil", sq: mat.NewSymDense(2, []float64{1, 0, 0, 1}), e: nil, expected: ErrNilMatrix},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+
+            if derivative, err := Frechet(table.sq, table.e); !reflect.DeepEqual(err, table.expected) || derivative != nil {
+                t.Errorf("Wrong result, got: %v, %v, want: nil, %v", derivative, err, table.expected)
+            }
+        })
+    }
+}
@@ -0,0 +1,136 @@
+package sqrtm
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/cmplx"
+)
+
+/*
+    The Exponential Method converges before its iterates become ill-conditioned only if the eigenvalues of C are of a similar magnitude,
+    see TestExponentialLargeDimensions. The preconditioning transforms C into factors that fulfill this requirement:
+    1. Scale C to unit spectral radius ρ: sqrt(C) = sqrt(ρ) sqrt(C / ρ).
+    2. If the ratio κ = |λ_max| / |λ_min| of the eigenvalues is at most maxDirectCondition, take the square root directly.
+    3. Otherwise, split C into the shifted matrix A = C + μI and the quotient B = A^{-1} C with μ = sqrt(|λ_min λ_max|).
+       A and B are functions of C, so they commute and sqrt(C) = sqrt(A) sqrt(B).
+       The eigenvalues λ + μ of A and λ / (λ + μ) of B have a ratio of at most sqrt(κ),
+       so after k splits the remaining ratio is κ^(1/2^k) and the square root of each factor is taken as in step 2.
+    4. Undo the transformation by multiplying the square roots of all factors and scaling the product by sqrt(ρ).
+*/
+
+const (
+    // ratio of the largest and smallest eigenvalue up to which the Exponential Method reliably reaches DefaultTolerance,
+    // at a ratio of 10 it already tends to stagnate slightly above
+    maxDirectCondition = 6
+    // with κ ≤ 1e308 the factors are well-conditioned after 9 splits
+    maxSplitDepth = 10
+)
+
+// returns the eigenvalues of c or nil if the factorization was not successful
+func eigenvalues(c mat.Matrix) []complex128 {
+    var eigen mat.Eigen
+
+    if !eigen.Factorize(c, mat.EigenNone) {
+        return nil
+    }
+
+    return eigen.Values(nil)
+}
+
+// returns the smallest and largest absolute value of the given eigenvalues, ok is false if there are none or one of them is 0
+func spectralBounds(values []complex128) (min, max float64, ok bool) {
+    min = math.Inf(1)
+
+    for _, value := range values {
+        abs := cmplx.Abs(value)
+        min = math.Min(min, abs)
+        max = math.Max(max, abs)
+    }
+
+    return min, max, len(values) > 0 && min > 0
+}
+
+func addToDiagonal(c mat.Matrix, mu float64) *mat.Dense {
+    shifted := mat.DenseCopyOf(c)
+    n, _ := shifted.Dims()
+
+    for i := 0; i < n; i++ {
+        shifted.Set(i, i, shifted.At(i, i) + mu)
+    }
+
+    return shifted
+}
+
+func splitSquareRoot(c mat.Matrix, depth int, tolerance float64, maxIterations int, onIteration func(Iteration), result *Result) (*mat.Dense, error) {
+    min, max, ok := spectralBounds(eigenvalues(c))
+
+    if !ok || max <= maxDirectCondition * min || depth == maxSplitDepth {
+        sq, r, err := exponential(c, tolerance, maxIterations, onIteration)
+        result.Iterations += r.Iterations
+
+        if r.Reason != Converged && result.Reason == Converged {
+            result.Reason = r.Reason
+        }
+
+        return sq, err
+    }
+
+    n, _ := c.Dims()
+    shifted := addToDiagonal(c, math.Sqrt(min * max))
+    quotient := mat.NewDense(n, n, nil)
+
+    if err := quotient.Solve(shifted, c); err != nil {
+        return nil, err
+    }
+
+    sqShifted, err := splitSquareRoot(shifted, depth + 1, tolerance, maxIterations, onIteration, result)
+
+    if err != nil {
+        return nil, err
+    }
+
+    sqQuotient, err := splitSquareRoot(quotient, depth + 1, tolerance, maxIterations, onIteration, result)
+
+    if err != nil {
+        return nil, err
+    }
+
+    sq := mat.NewDense(n, n, nil)
+    sq.Mul(sqShifted, sqQuotient)
+    return sq, nil
+}
+
+/*
+    Same as exponential, but applies the preconditioning described above to c with the given eigenvalues.
+    The returned Result sums up the iterations of all factors, its residual refers to c.
+    onIteration is called for the iterations of each factor, which start again at 1.
+    Inputs whose eigenvalues can not be bounded away from 0 are passed to exponential unchanged.
+*/
+func preconditioned(c mat.Matrix, values []complex128, tolerance float64, maxIterations int, onIteration func(Iteration)) (*mat.Dense, Result, error) {
+    _, max, ok := spectralBounds(values)
+
+    if !ok {
+        return exponential(c, tolerance, maxIterations, onIteration)
+    }
+
+    n, _ := c.Dims()
+    scaled := mat.NewDense(n, n, nil)
+    scaled.Scale(1 / max, c)
+
+    result := Result{Reason: Converged}
+    sq, err := splitSquareRoot(scaled, 0, tolerance, maxIterations, onIteration, &result)
+
+    if err != nil {
+        result.Residual = math.NaN()
+        return nil, result, err
+    }
+
+    sq.Scale(math.Sqrt(max), sq)
+    result.Residual = residual(c, sq)
+
+    if !(result.Residual <= tolerance) {
+        return sq, result, &NotConvergedError{Result: result}
+    }
+
+    return sq, result, nil
+}
@@ -0,0 +1,122 @@
+package sqrtm
+
+import (
+    "fmt"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/rand"
+    "testing"
+)
+
+func randomOrthogonal(n int, seed int64) *mat.Dense {
+    rnd := rand.New(rand.NewSource(seed))
+    data := make([]float64, n * n)
+    for i := range data {
+        data[i] = rnd.NormFloat64()
+    }
+    var qr mat.QR
+    qr.Factorize(mat.NewDense(n, n, data))
+    var q mat.Dense
+    qr.QTo(&q)
+    return &q
+}
+
+// returns W diag(values) Wᵀ for a random orthogonal W and its exact square root W diag(sqrt(values)) Wᵀ
+func withEigenvalues(values []float64, seed int64) (c, sq *mat.Dense) {
+    n := len(values)
+    w := randomOrthogonal(n, seed)
+    roots := make([]float64, n)
+    for i, v := range values {
+        roots[i] = math.Sqrt(v)
+    }
+    c = mat.NewDense(n, n, nil)
+    c.Product(w, mat.NewDiagDense(n, values), w.T())
+    // remove the rounding errors of the product that make c slightly non symmetric
+    c.Add(c, c.T())
+    c.Scale(0.5, c)
+    sq = mat.NewDense(n, n, nil)
+    sq.Product(w, mat.NewDiagDense(n, roots), w.T())
+    return
+}
+
+func logSpaced(n int, from, to float64) []float64 {
+    values := make([]float64, n)
+    for i := range values {
+        values[i] = math.Pow(10, math.Log10(from) + (math.Log10(to) - math.Log10(from)) * float64(i) / float64(n - 1))
+    }
+    return values
+}
+
+func TestPreconditioned(t *testing.T) {
+    tables := []struct {
+        desc string
+        eigenvalues []float64
+    }{
+        {desc: "eigenvalues of similar magnitude", eigenvalues: []float64{0.5, 0.7, 0.9, 1}},
+        {desc: "eigenvalues from 1e-4 to 1", eigenvalues: logSpaced(8, 1e-4, 1)},
+        {desc: "eigenvalues from 1e-10 to 1", eigenvalues: logSpaced(10, 1e-10, 1)},
+        {desc: "eigenvalues from 1e-10 to 1 in dimension 40", eigenvalues: logSpaced(40, 1e-10, 1)},
+        {desc: "eigenvalues close to 0 and 1", eigenvalues: []float64{1e-10, 2e-10, 1 - 1e-10, 1}},
+        {desc: "large eigenvalues", eigenvalues: logSpaced(6, 1, 1e8)},
+    }
+
+    for i, table := range tables {
+        table, seed := table, int64(i)
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            c, expected := withEigenvalues(table.eigenvalues, seed)
+
+            sq, result, err := preconditioned(c, eigenvalues(c), DefaultTolerance, DefaultMaxIterations, nil)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            if result.Reason != Converged || result.Residual > DefaultTolerance {
+                t.Errorf("Wrong result: %+v", result)
+            }
+
+            diff := mat.NewDense(len(table.eigenvalues), len(table.eigenvalues), nil)
+            diff.Sub(sq, expected)
+
+            if e := mat.Norm(diff, 2) / mat.Norm(expected, 2); e > 1e-9 {
+                t.Errorf("Wrong square root, relative error %e", e)
+            }
+        })
+    }
+}
+
+func TestPreconditionedImprovesAccuracy(t *testing.T) {
+    c, _ := withEigenvalues(logSpaced(10, 1e-10, 1), 42)
+
+    if _, result, err := exponential(c, DefaultTolerance, DefaultMaxIterations, nil); err == nil {
+        t.Errorf("Expected the Exponential Method to fail without preconditioning, got: %+v", result)
+    }
+
+    if _, result, err := preconditioned(c, eigenvalues(c), DefaultTolerance, DefaultMaxIterations, nil); err != nil {
+        t.Errorf("Unexpected error: %v, %+v", err, result)
+    }
+}
+
+func TestPreconditionedFallback(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+    }{
+        {desc: "for singular matrix", value: mat.NewDense(2, 2, []float64{1,0,0,0})},
+        {desc: "for zero matrix", value: mat.NewDense(2, 2, nil)},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            _, expectedResult, expectedErr := exponential(table.value, DefaultTolerance, DefaultMaxIterations, nil)
+            _, result, err := preconditioned(table.value, eigenvalues(table.value), DefaultTolerance, DefaultMaxIterations, nil)
+
+            if fmt.Sprint(err) != fmt.Sprint(expectedErr) || fmt.Sprint(result) != fmt.Sprint(expectedResult) {
+                t.Errorf("Preconditioning was not skipped, got: %v, %+v, want: %v, %+v", err, result, expectedErr, expectedResult)
+            }
+        })
+    }
+}
@@ -0,0 +1,302 @@
+/*
+    Package sqrtm calculates the square root of a square matrix with non-negative eigenvalues.
+
+    The default algorithm is the Exponential Method for Matrices, preconditioned so that inputs with eigenvalues of different magnitudes are handled accurately.
+    For symmetric positive semidefinite matrices the square root can also be calculated from an eigendecomposition.
+*/
+package sqrtm
+
+import (
+    "errors"
+    "fmt"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "math/cmplx"
+)
+
+// Algorithm to calculate a square root
+type Algorithm int
+
+const (
+    // the Exponential Method for Matrices with preconditioning, which works for any matrix with eigenvalues off the closed negative real axis
+    Exponential Algorithm = iota
+    // the Exponential Method for Matrices without preconditioning
+    ExponentialUnpreconditioned
+    // the eigendecomposition of a symmetric matrix, which also works for singular positive semidefinite matrices
+    Eigen
+)
+
+const (
+    // relative residual a square root needs to reach by default
+    DefaultTolerance = 1e-10
+    DefaultMaxIterations = 100
+    // eigenvalues down to −DefaultEigenvalueTolerance times the spectral radius are treated as 0 by default
+    DefaultEigenvalueTolerance = 1e-12
+)
+
+// Settings of a square root calculation, the zero value of each field selects its default
+type Settings struct {
+    Algorithm Algorithm
+    // relative residual ‖Q² − A‖_F / ‖A‖_F the square root Q has to reach, DefaultTolerance if 0
+    Tolerance float64
+    // maximum number of iterations of the Exponential Method, DefaultMaxIterations if 0
+    MaxIterations int
+    // eigenvalues down to −EigenvalueTolerance times the spectral radius are treated as 0, DefaultEigenvalueTolerance if 0
+    EigenvalueTolerance float64
+    // called after each iteration of the Exponential Method if not nil
+    OnIteration func(Iteration)
+}
+
+// State of the Exponential Method after one iteration
+type Iteration struct {
+    // number of the iteration, starting at 1 for each factor of the preconditioning
+    Iteration int
+    // relative residual ‖Q² − C‖_F / ‖C‖_F of the square root Q of this iteration, where C is the matrix or factor whose square root is calculated
+    Residual float64
+}
+
+func (s *Settings) withDefaults() Settings {
+    settings := Settings{}
+
+    if s != nil {
+        settings = *s
+    }
+
+    if settings.Tolerance == 0 {
+        settings.Tolerance = DefaultTolerance
+    }
+
+    if settings.MaxIterations == 0 {
+        settings.MaxIterations = DefaultMaxIterations
+    }
+
+    if settings.EigenvalueTolerance == 0 {
+        settings.EigenvalueTolerance = DefaultEigenvalueTolerance
+    }
+
+    return settings
+}
+
+// Reason why the calculation stopped
+type StopReason int
+
+const (
+    // the residual reached the requested tolerance
+    Converged StopReason = iota
+    // the iterates became too ill-conditioned to continue
+    IllConditioned
+    // the residual did not improve in the last iterations, usually because rounding errors dominate
+    Stagnated
+    // the maximum number of iterations was reached
+    MaxIterationsReached
+)
+
+func (r StopReason) String() string {
+    switch r {
+    case Converged:
+        return "converged"
+    case IllConditioned:
+        return "ill-conditioned"
+    case Stagnated:
+        return "stagnated"
+    case MaxIterationsReached:
+        return "maximum number of iterations reached"
+    }
+    return fmt.Sprintf("StopReason(%d)", int(r))
+}
+
+// Statistics of a square root calculation
+type Result struct {
+    // number of iterations of the Exponential Method, summed up over all preconditioned factors, and 0 for Eigen
+    Iterations int
+    // relative residual ‖Q² − A‖_F / ‖A‖_F of the returned square root Q
+    Residual float64
+    Reason StopReason
+}
+
+var (
+    // returned for a nil input
+    ErrNilMatrix = guard.ErrNilMatrix
+    // returned for an input without rows or columns
+    ErrEmptyMatrix = guard.ErrEmptyMatrix
+)
+
+// returned instead of a panic, e.g. of gonum or of a mat.Matrix implementation passed as input
+type PanicError = guard.PanicError
+
+// returned for an input with a NaN or ±Inf entry (Stage is StageInput) or if the linear solve of the Exponential Method
+// produced only non-finite square roots (Stage is StageSolve)
+type NonFiniteError = guard.NonFiniteError
+
+// Stage of a NonFiniteError
+type Stage = guard.Stage
+
+const (
+    StageInput = guard.StageInput
+    StageSolve = guard.StageSolve
+)
+
+// returned for an input that does not have square dimension
+var ErrNotSquare = errors.New("Matrix does not have square dimension")
+
+// returned if the Eigen algorithm is selected for an input that is not symmetric
+var ErrNotSymmetric = errors.New("Matrix is not symmetric")
+
+// returned for an input with an eigenvalue on the negative real axis, which has no real square root
+type NotPositiveSemidefiniteError struct {
+    Eigenvalue complex128
+}
+
+func (e *NotPositiveSemidefiniteError) Error() string {
+    return fmt.Sprintf("Matrix is not positive semidefinite: eigenvalue %v", e.Eigenvalue)
+}
+
+// returned if the square root did not reach the requested tolerance
+type NotConvergedError struct {
+    Result Result
+}
+
+func (e *NotConvergedError) Error() string {
+    return fmt.Sprintf("Accuracy not reached: relative residual %e after %d iterations (%v)", e.Result.Residual, e.Result.Iterations, e.Result.Reason)
+}
+
+// returns ‖Q² − C‖_F / ‖C‖_F or the absolute residual if C is zero
+func residual(c mat.Matrix, q *mat.Dense) float64 {
+    n, _ := q.Dims()
+    diff := mat.NewDense(n, n, nil)
+    diff.Mul(q, q)
+    diff.Sub(diff, c)
+
+    if norm := mat.Norm(c, 2); norm != 0 {
+        return mat.Norm(diff, 2) / norm
+    }
+
+    return mat.Norm(diff, 2)
+}
+
+// returns an error for the first eigenvalue on the negative real axis, eigenvalues down to −tolerance times the spectral radius are accepted
+func checkEigenvalues(values []complex128, tolerance float64) error {
+    radius := 0.0
+
+    for _, value := range values {
+        radius = math.Max(radius, cmplx.Abs(value))
+    }
+
+    for _, value := range values {
+        if real(value) < -tolerance * radius && math.Abs(imag(value)) <= tolerance * radius {
+            return &NotPositiveSemidefiniteError{Eigenvalue: value}
+        }
+    }
+
+    return nil
+}
+
+func isSymmetric(a mat.Matrix) bool {
+    if _, ok := a.(mat.Symmetric); ok {
+        return true
+    }
+
+    return mat.Equal(a, a.T())
+}
+
+/*
+    Returns the principal square root Q of a with Q² = a and the statistics of the calculation.
+    A nil settings selects the default settings.
+    The error is ErrNilMatrix, ErrEmptyMatrix, a *NonFiniteError, ErrNotSquare, ErrNotSymmetric, a *NotPositiveSemidefiniteError or a *NotConvergedError.
+    For a *NotConvergedError the most accurate square root found is returned as well.
+*/
+func Sqrt(a mat.Matrix, settings *Settings) (sq *mat.Dense, result Result, err error) {
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(a); err != nil {
+        return nil, Result{}, err
+    }
+
+    // gonum's eigendecomposition does not terminate for non-finite input
+    if err := guard.CheckFinite(guard.StageInput, a); err != nil {
+        return nil, Result{}, err
+    }
+
+    s := settings.withDefaults()
+    m, n := a.Dims()
+
+    if m != n {
+        return nil, Result{}, ErrNotSquare
+    }
+
+    if s.Algorithm == Eigen {
+        if !isSymmetric(a) {
+            return nil, Result{}, ErrNotSymmetric
+        }
+
+        sym, ok := a.(mat.Symmetric)
+
+        if !ok {
+            sym = mat.NewSymDense(n, mat.DenseCopyOf(a).RawMatrix().Data)
+        }
+
+        sq, result, err := SqrtSym(sym, settings)
+
+        if sq == nil {
+            return nil, result, err
+        }
+
+        return mat.DenseCopyOf(sq), result, err
+    }
+
+    values := eigenvalues(a)
+
+    if err := checkEigenvalues(values, s.EigenvalueTolerance); err != nil {
+        return nil, Result{}, err
+    }
+
+    if s.Algorithm == ExponentialUnpreconditioned {
+        return exponential(a, s.Tolerance, s.MaxIterations, s.OnIteration)
+    }
+
+    return preconditioned(a, values, s.Tolerance, s.MaxIterations, s.OnIteration)
+}
+
+/*
+    Returns the symmetric positive semidefinite square root of the symmetric positive semidefinite a.
+    With the Exponential algorithms, the result is symmetrized. Errors are reported as in Sqrt.
+*/
+func SqrtSym(a mat.Symmetric, settings *Settings) (sq *mat.SymDense, result Result, err error) {
+    defer guard.Recover(&err)
+
+    if err := guard.CheckMatrix(a); err != nil {
+        return nil, Result{}, err
+    }
+
+    if err := guard.CheckFinite(guard.StageInput, a); err != nil {
+        return nil, Result{}, err
+    }
+
+    s := settings.withDefaults()
+
+    if s.Algorithm == Eigen {
+        return eigenSqrt(a, s)
+    }
+
+    dense, result, err := Sqrt(a, &s)
+
+    if dense == nil {
+        return nil, result, err
+    }
+
+    return symmetrize(dense), result, err
+}
+
+func symmetrize(a *mat.Dense) *mat.SymDense {
+    n, _ := a.Dims()
+    sym := mat.NewSymDense(n, nil)
+
+    for i := 0; i < n; i++ {
+        for j := i; j < n; j++ {
+            sym.SetSym(i, j, (a.At(i, j) + a.At(j, i)) / 2)
+        }
+    }
+
+    return sym
+}
@@ -0,0 +1,178 @@
+package sqrtm
+
+import (
+    "gonum.org/v1/gonum/mat"
+    "math"
+    "reflect"
+    "testing"
+)
+
+func squareResidual(a mat.Matrix, sq mat.Matrix) float64 {
+    n, _ := a.Dims()
+    diff := mat.NewDense(n, n, nil)
+    diff.Mul(sq, sq)
+    diff.Sub(diff, a)
+    return mat.Norm(diff, 2) / mat.Norm(a, 2)
+}
+
+func TestSqrt(t *testing.T) {
+    illConditioned, _ := withEigenvalues(logSpaced(8, 1e-10, 1), 7)
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        settings *Settings
+    }{
+        {desc: "default settings", value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), settings: nil},
+        {desc: "default settings for non symmetric matrix", value: mat.NewDense(3,3, []float64{0.1,0,0.3,0, 0.12, 0.1412,0, 0, 0.12}), settings: nil},
+        {desc: "default settings for ill-conditioned matrix", value: illConditioned, settings: nil},
+        {desc: "symmetric matrix", value: mat.NewSymDense(2, []float64{0.5,-0.25,-0.25,0.75,}), settings: &Settings{}},
+        {desc: "unpreconditioned", value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), settings: &Settings{Algorithm: ExponentialUnpreconditioned}},
+        {desc: "eigen for dense matrix", value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), settings: &Settings{Algorithm: Eigen}},
+        {desc: "eigen for ill-conditioned matrix", value: illConditioned, settings: &Settings{Algorithm: Eigen}},
+        {desc: "eigen for singular matrix", value: mat.NewSymDense(2, []float64{1,1,1,1,}), settings: &Settings{Algorithm: Eigen}},
+        {desc: "custom tolerance", value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}), settings: &Settings{Tolerance: 1e-4}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            sq, result, err := Sqrt(table.value, table.settings)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            tolerance := table.settings.withDefaults().Tolerance
+
+            if result.Reason != Converged || result.Residual > tolerance {
+                t.Errorf("Wrong result: %+v", result)
+            }
+
+            if r := squareResidual(table.value, sq); r != result.Residual {
+                t.Errorf("Wrong residual, got: %e, want: %e", result.Residual, r)
+            }
+        })
+    }
+}
+
+func TestSqrtSym(t *testing.T) {
+    for _, algorithm := range []Algorithm{Exponential, ExponentialUnpreconditioned, Eigen} {
+        value := mat.NewSymDense(3, []float64{2, -1, 0, -1, 2, -1, 0, -1, 2})
+        sq, result, err := SqrtSym(value, &Settings{Algorithm: algorithm})
+
+        if err != nil {
+            t.Fatalf("Unexpected error for algorithm %d: %v", algorithm, err)
+        }
+
+        if r := squareResidual(value, sq); r > DefaultTolerance || result.Residual > DefaultTolerance {
+            t.Errorf("Wrong square root for algorithm %d, residual: %e", algorithm, r)
+        }
+    }
+}
+
+func TestSqrtErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        settings *Settings
+        expectedErr error
+    }{
+        {desc: "nil", value: nil, settings: nil, expectedErr: ErrNilMatrix},
+        {desc: "empty", value: &mat.Dense{}, settings: nil, expectedErr: ErrEmptyMatrix},
+        {desc: "NaN", value: mat.NewDense(2, 2, []float64{1, 0, 0, math.NaN()}), settings: nil, expectedErr: &NonFiniteError{}},
+        {desc: "Inf for eigen", value: mat.NewSymDense(2, []float64{math.Inf(1), 0, 0, 1}), settings: &Settings{Algorithm: Eigen}, expectedErr: &NonFiniteError{}},
+        {desc: "not square", value: mat.NewDense(2, 3, nil), settings: nil, expectedErr: ErrNotSquare},
+        {desc: "not symmetric for eigen", value: mat.NewDense(2, 2, []float64{1,1,0,1}), settings: &Settings{Algorithm: Eigen}, expectedErr: ErrNotSymmetric},
+        {desc: "negative eigenvalue", value: mat.NewDense(2, 2, []float64{1,0,0,-1}), settings: nil, expectedErr: &NotPositiveSemidefiniteError{Eigenvalue: -1}},
+        {desc: "negative eigenvalue for eigen", value: mat.NewSymDense(2, []float64{1,0,0,-1}), settings: &Settings{Algorithm: Eigen}, expectedErr: &NotPositiveSemidefiniteError{Eigenvalue: -1}},
+        {
+            desc: "not converged",
+            value: mat.NewDense(2, 2, []float64{0.5,-0.25,-0.25,0.75,}),
+            settings: &Settings{Algorithm: ExponentialUnpreconditioned, MaxIterations: 1},
+            expectedErr: &NotConvergedError{},
+        },
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            _, _, err := Sqrt(table.value, table.settings)
+
+            if reflect.TypeOf(err) != reflect.TypeOf(table.expectedErr) {
+                t.Fatalf("Wrong error, got: %v, want: %v", err, table.expectedErr)
+            }
+
+            if e, ok := err.(*NotPositiveSemidefiniteError); ok && !reflect.DeepEqual(e, table.expectedErr) {
+                t.Errorf("Wrong error, got: %v, want: %v", err, table.expectedErr)
+            }
+
+            if e, ok := err.(*NonFiniteError); ok && e.Stage != StageInput {
+                t.Errorf("Wrong stage, got: %v, want: %v", e.Stage, StageInput)
+            }
+
+            if e, ok := err.(*NotConvergedError); ok && (e.Result.Iterations != 1 || e.Result.Reason != MaxIterationsReached) {
+                t.Errorf("Wrong statistics in error: %+v", e.Result)
+            }
+
+            if err == ErrNotSquare || err == ErrNotSymmetric || err == ErrNilMatrix || err == ErrEmptyMatrix {
+                if err != table.expectedErr {
+                    t.Errorf("Wrong error, got: %v, want: %v", err, table.expectedErr)
+                }
+            }
+        })
+    }
+}
+
+func TestSettingsWithDefaults(t *testing.T) {
+    expected := Settings{Algorithm: Exponential, Tolerance: DefaultTolerance, MaxIterations: DefaultMaxIterations, EigenvalueTolerance: DefaultEigenvalueTolerance}
+    var settings *Settings
+
+    if got := settings.withDefaults(); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Wrong defaults for nil settings, got: %+v, want: %+v", got, expected)
+    }
+
+    custom := Settings{Algorithm: Eigen, Tolerance: 1e-3, MaxIterations: 5, EigenvalueTolerance: 1e-6}
+
+    if got := custom.withDefaults(); !reflect.DeepEqual(got, custom) {
+        t.Errorf("Custom settings were overwritten, got: %+v, want: %+v", got, custom)
+    }
+}
+
+func TestSqrtSymNil(t *testing.T) {
+    var nilSym *mat.SymDense
+
+    if sq, _, err := SqrtSym(nilSym, nil); sq != nil || err != ErrNilMatrix {
+        t.Errorf("SqrtSym returned wrong value, got: %v, %v, want: nil, %v", sq, err, ErrNilMatrix)
+    }
+}
+
+func TestSqrtOnIteration(t *testing.T) {
+    for _, algorithm := range []Algorithm{Exponential, ExponentialUnpreconditioned} {
+        var iterations []Iteration
+        settings := &Settings{Algorithm: algorithm, OnIteration: func(i Iteration) {
+            iterations = append(iterations, i)
+        }}
+
+        _, result, err := SqrtSym(mat.NewSymDense(2, []float64{0.5,-0.25,-0.25,0.75,}), settings)
+
+        if err != nil {
+            t.Fatalf("Unexpected error for %v: %v", algorithm, err)
+        }
+
+        if len(iterations) != result.Iterations {
+            t.Errorf("Wrong number of callbacks for %v, got: %d, want: %d", algorithm, len(iterations), result.Iterations)
+        }
+
+        if last := iterations[len(iterations) - 1]; last.Residual > DefaultTolerance || last.Iteration != len(iterations) {
+            t.Errorf("Wrong last iteration for %v, got: %+v", algorithm, last)
+        }
+    }
+
+    if _, _, err := Sqrt(mat.NewDense(1, 1, []float64{4}), &Settings{Algorithm: Eigen, OnIteration: func(Iteration) {
+        t.Errorf("Unexpected callback for Eigen")
+    }}); err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+}
@@ -0,0 +1,85 @@
+package godilation
+
+import (
+    "github.com/acra5y/go-dilation/internal/dilation"
+    "github.com/acra5y/go-dilation/internal/guard"
+    "github.com/acra5y/go-dilation/interval"
+    "gonum.org/v1/gonum/mat"
+    "time"
+)
+
+// returned by UnitaryNDilationVerified if it could not prove its result, e.g. for a contraction with ‖T‖₂ too close to 1
+type VerificationError = guard.VerificationError
+
+// The result of UnitaryNDilationVerified, all bounds are rigorous
+type VerifiedDilation struct {
+    // encloses every entry of the exact unitary n-dilation of t
+    Enclosure *interval.Dense
+    // the midpoints of Enclosure
+    Dilation *mat.Dense
+    // an upper bound of ‖T‖₂, t is proven to be a contraction with ‖T‖₂ < 1 even if NormBound is 1
+    NormBound float64
+    // an upper bound of ‖UᵀU - I‖_F for U = Dilation
+    UnitarityBound float64
+    // CompressionBounds[k - 1] is an upper bound of ‖P U^k Pᵀ - T^k‖_F for U = Dilation and k = 1, ..., n, where P Pᵀ projects onto the first block
+    CompressionBounds []float64
+}
+
+func (o *options) verifiedDefects() func(mat.Matrix) (*interval.Dense, *interval.Dense, float64, error) {
+    // the enclosure needs positive definite approximations of the square roots
+    calculate := dilation.VerifiedSquareRootDefects(o.symmetricSquareRoot)
+
+    return func(t mat.Matrix) (*interval.Dense, *interval.Dense, float64, error) {
+        start := time.Now()
+        defect, defectOfTransposed, normBound, err := calculate(t)
+        o.observer.StageDone(StageEvent{Stage: StageDefects, Duration: time.Since(start), Err: err})
+        return defect, defectOfTransposed, normBound, err
+    }
+}
+
+/*
+    Same as UnitaryNDilation, but verifies the dilation with interval arithmetic with outward rounding.
+    T is proven to be a contraction by an interval Cholesky decomposition of I - TTᵀ and the exact square roots are enclosed around the approximations
+    of an eigendecomposition. The bounds of the result hold for the exact calculation, not only up to rounding errors.
+    The calculation takes O(N³) interval operations for the dilation of dimension N. WithBackend is ignored.
+    Unlike UnitaryNDilation, t must be square, a rectangular t results in the error "Matrix does not have square dimension".
+    Returns "Input is not a contraction" only if this is proven as well, and a *VerificationError if neither could be proven.
+    With WithNearestContraction the result is verified for the nearest contraction of t, which is calculated in float64.
+    WithSpecialOrthogonal negates the last column of the enclosure, if the determinant of every enclosed matrix is proven to be negative,
+    so the bounds hold for the special orthogonal dilation. A *VerificationError is returned, if the sign could not be proven.
+*/
+func UnitaryNDilationVerified(t mat.Matrix, n int, opts ...Option) (verified *VerifiedDilation, err error) {
+    defer guard.Recover(&err)
+
+    if err := validate(t, n); err != nil {
+        return nil, err
+    }
+
+    o := newOptions(opts)
+
+    if t, err = o.projected(t); err != nil {
+        return nil, err
+    }
+
+    enclosure, normBound, err := dilation.UnitaryNDilationVerified(o.verifiedDefects(), t, n)
+
+    if err != nil {
+        return nil, err
+    }
+
+    if o.special {
+        if err := dilation.SpecialOrthogonalVerified(enclosure); err != nil {
+            return nil, err
+        }
+    }
+
+    unitary := enclosure.Mid()
+
+    return &VerifiedDilation{
+        Enclosure: enclosure,
+        Dilation: unitary,
+        NormBound: normBound,
+        UnitarityBound: dilation.UnitarityBound(unitary),
+        CompressionBounds: dilation.CompressionBounds(unitary, t, n),
+    }, nil
+}
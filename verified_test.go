@@ -0,0 +1,115 @@
+package godilation
+
+import (
+    "fmt"
+    "github.com/acra5y/go-dilation/bigmat"
+    "github.com/acra5y/go-dilation/interval"
+    "gonum.org/v1/gonum/mat"
+    "math/big"
+    "reflect"
+    "testing"
+)
+
+// reports whether every entry of exact is contained in the corresponding interval of enclosure
+func enclosesBig(enclosure *interval.Dense, exact *bigmat.Dense) bool {
+    r, c := exact.Dims()
+
+    for i := 0; i < r; i++ {
+        for j := 0; j < c; j++ {
+            v, x := enclosure.At(i, j), exact.At(i, j)
+
+            if big.NewFloat(v.Lo).Cmp(x) > 0 || big.NewFloat(v.Hi).Cmp(x) < 0 {
+                return false
+            }
+        }
+    }
+
+    return true
+}
+
+func operatorNorm(m mat.Matrix) float64 {
+    var svd mat.SVD
+    svd.Factorize(m, mat.SVDNone)
+    return svd.Values(nil)[0]
+}
+
+func TestUnitaryNDilationVerified(t *testing.T) {
+    tables := []struct {
+        desc string
+        value *mat.Dense
+        degree int
+    }{
+        {desc: "matrix of examples/basic.go", value: mat.NewDense(2, 2, []float64{0.5,0.5,0,0.5,}), degree: 2},
+        {desc: "random contraction", value: randomContraction(4, 0.8), degree: 3},
+        {desc: "norm close to 1", value: mat.NewDense(2, 2, []float64{0.999999,0,0,0.1,}), degree: 2},
+        {desc: "ill-conditioned defect operators", value: contractionWithDefectEigenvalues([]float64{1e-8, 1e-4, 1}), degree: 2},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            verified, err := UnitaryNDilationVerified(table.value, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error: %v", err)
+            }
+
+            // a reference with 256 bits, whose distance to the exact dilation is far below the width of the enclosure
+            exact, _, err := UnitaryNDilationBigFloat(table.value, table.degree)
+
+            if err != nil {
+                t.Fatalf("Unexpected error of reference: %v", err)
+            }
+
+            if !enclosesBig(verified.Enclosure, exact) {
+                t.Errorf("Enclosure does not contain the dilation")
+            }
+
+            if norm := operatorNorm(table.value); verified.NormBound < norm || verified.NormBound > 1 {
+                t.Errorf("Wrong norm bound: %v for norm %v", verified.NormBound, norm)
+            }
+
+            if verified.UnitarityBound > 1e-10 || verified.UnitarityBound < unitarityDeviation(verified.Dilation) {
+                t.Errorf("Wrong unitarity bound: %e", verified.UnitarityBound)
+            }
+
+            if len(verified.CompressionBounds) != table.degree {
+                t.Fatalf("Wrong number of compression bounds: %d", len(verified.CompressionBounds))
+            }
+
+            for k, bound := range verified.CompressionBounds {
+                if bound > 1e-10 {
+                    t.Errorf("Compression bound of power %d too large: %e", k + 1, bound)
+                }
+            }
+        })
+    }
+}
+
+func TestUnitaryNDilationVerifiedErrors(t *testing.T) {
+    tables := []struct {
+        desc string
+        value mat.Matrix
+        degree int
+        expected error
+    }{
+        {desc: "proven not a contraction", value: mat.NewDense(2, 2, []float64{0.5,0.9,0,0.5,}), degree: 2, expected: fmt.Errorf("Input is not a contraction")},
+        {desc: "norm 1", value: mat.NewDense(1, 1, []float64{1}), degree: 2, expected: &VerificationError{Stage: StagePositiveDefiniteCheck, Reason: "could not prove that I - TTᵀ is positive definite"}},
+        {desc: "not square", value: mat.NewDense(1, 2, []float64{0.5, 0}), degree: 2, expected: fmt.Errorf("Matrix does not have square dimension")},
+        {desc: "nil", value: nil, degree: 2, expected: ErrNilMatrix},
+        {desc: "degree", value: mat.NewDense(1, 1, []float64{0.5}), degree: 0, expected: &DegreeError{Degree: 0}},
+    }
+
+    for _, table := range tables {
+        table := table
+        t.Run(table.desc, func(t *testing.T) {
+            t.Parallel()
+            verified, err := UnitaryNDilationVerified(table.value, table.degree)
+
+            if !reflect.DeepEqual(err, table.expected) || verified != nil {
+                t.Errorf("Wrong result, got: %v, %v, want: nil, %v", verified, err, table.expected)
+            }
+        })
+    }
+}